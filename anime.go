@@ -1,19 +1,115 @@
 package animenames
 
+import (
+	"fmt"
+	"time"
+)
+
 // Anime contains information about an anime file or directory.
 type Anime struct {
 	Title   string
 	Year    int
+	YearEnd int // e.g. `2015` in "(2013-2015)"
 	Episode int
 	Season  int // e.g. `2` in "Nisekoi S2"
 	Volume  int
 	Batch   *Batch
 	Group   string
+	Groups  []string // e.g. `["UTW", "Mazui", "MK"]` in "[UTW-Mazui-MK]"
 	CRC32   string
 
+	Checksum      *Checksum     // e.g. MD5 or SHA-1, besides CRC32
+	TotalEpisodes int           // e.g. `12` in "Episode 03 of 12"
+	AiringSeason  string        // e.g. "Winter" in "Winter 2023"
+	Platform      string        // e.g. "Crunchyroll" in "[CR WEB-DL 1080p]"
+	Resolution    string        // e.g. "1080p" in "[1920x1080]"
+	Language      string        // e.g. "zh-Hans" in "[Group][Title][01][GB]"
+	Origin        string        // e.g. "www.site.com" in "[www.site.com] Title - 01"
+	HDR           HDRFormat     // e.g. `HDR10` in "[2160p HDR10]"
+	FrameRate     string        // e.g. "23.976" or "60" in "[60fps]"
+	Size          int64         // In bytes, e.g. from "[1.2GB]"
+	Duration      time.Duration // e.g. from "[23m40s]"
+	ReleaseDate   time.Time     // e.g. from "[2023-10-05]"
+	Revision      Revision      // e.g. from "03v2" or "REPACK"
+
+	TitleNative string // e.g. "進撃の巨人" in "進撃の巨人 / Shingeki no Kyojin"
+	TitleRomaji string // e.g. "Shingeki no Kyojin" in "進撃の巨人 / Shingeki no Kyojin"
+
 	IsOVA       bool
 	IsBD        bool
+	IsRaw       bool // Without subtitles, e.g. "[Ohys-Raws]"
 	HasSpecials bool
+
+	Subs SubsType
+
+	// Fields contains information found by extractors, e.g.
+	// `{"studio": "MAPPA"}`.
+	Fields map[string]string
+
+	// Spans contains where each field was found in the name.
+	Spans Spans
+}
+
+// SetField sets a custom field, for information without its own field in
+// `Anime`.
+func (a *Anime) SetField(key, value string) {
+	if a.Fields == nil {
+		a.Fields = map[string]string{}
+	}
+
+	a.Fields[key] = value
+}
+
+// SubsType describes how subtitles are included in a release.
+type SubsType int
+
+const (
+	SubsUnknown SubsType = iota
+	SubsHard             // e.g. "[Hardsub]"
+	SubsSoft             // e.g. "[Softsubs]"
+)
+
+// String returns the name of s, e.g. "Hard" for `SubsHard`.
+func (s SubsType) String() string {
+	switch s {
+	case SubsUnknown:
+		return "Unknown"
+	case SubsHard:
+		return "Hard"
+	case SubsSoft:
+		return "Soft"
+	}
+
+	return fmt.Sprintf("SubsType(%d)", int(s))
+}
+
+// HDRFormat describes the dynamic range of a video.
+type HDRFormat int
+
+const (
+	HDRNone HDRFormat = iota
+	HDR10
+	HDR10Plus
+	HDRDolbyVision
+	HDRHLG
+)
+
+// String returns the name of f, e.g. "HDR10+" for `HDR10Plus`.
+func (f HDRFormat) String() string {
+	switch f {
+	case HDRNone:
+		return "None"
+	case HDR10:
+		return "HDR10"
+	case HDR10Plus:
+		return "HDR10+"
+	case HDRDolbyVision:
+		return "Dolby Vision"
+	case HDRHLG:
+		return "HLG"
+	}
+
+	return fmt.Sprintf("HDRFormat(%d)", int(f))
 }
 
 // Batch describes a batch.
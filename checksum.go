@@ -0,0 +1,137 @@
+package animenames
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ChecksumAlgorithm is the algorithm used to compute a checksum.
+type ChecksumAlgorithm string
+
+const (
+	ChecksumCRC32  ChecksumAlgorithm = "CRC32"
+	ChecksumMD5    ChecksumAlgorithm = "MD5"
+	ChecksumED2K   ChecksumAlgorithm = "ED2K"
+	ChecksumSHA1   ChecksumAlgorithm = "SHA1"
+	ChecksumSHA256 ChecksumAlgorithm = "SHA256"
+)
+
+// Checksum describes a checksum found in a name.
+type Checksum struct {
+	Algorithm ChecksumAlgorithm
+	Value     string // Upper-case, e.g. "05BD70FE"
+}
+
+// checksumLengths maps the number of hex digits of a checksum to the
+// algorithm assumed when there's no explicit prefix.
+//
+// MD5 and ED2K hashes have the same length, so ED2K hashes must be written
+// with a prefix (e.g. "ed2k:...").
+var checksumLengths = map[int]ChecksumAlgorithm{
+	8:  ChecksumCRC32,
+	32: ChecksumMD5,
+	40: ChecksumSHA1,
+	64: ChecksumSHA256,
+}
+
+// checksumPrefixes maps lowercase algorithm prefixes (as in "md5:...") to
+// their algorithm.
+var checksumPrefixes = map[string]ChecksumAlgorithm{
+	"crc32":  ChecksumCRC32,
+	"ed2k":   ChecksumED2K,
+	"md5":    ChecksumMD5,
+	"sha1":   ChecksumSHA1,
+	"sha256": ChecksumSHA256,
+}
+
+// parseChecksum returns the checksum in word, which is either a hex string
+// with the length of a known algorithm (e.g. "05BD70FE"), or a hex string
+// prefixed by its algorithm (e.g. "ed2k:...").
+func parseChecksum(word string) (*Checksum, bool) {
+	algorithm := ChecksumAlgorithm("")
+
+	if i := strings.Index(word, ":"); i != -1 {
+		var ok bool
+
+		algorithm, ok = checksumPrefixes[strings.ToLower(word[:i])]
+		if !ok {
+			return nil, false
+		}
+
+		word = word[i+1:]
+	}
+
+	if !isHex(word) {
+		return nil, false
+	}
+
+	expected, ok := checksumLengths[len(word)]
+	if !ok {
+		return nil, false
+	}
+
+	if algorithm == "" {
+		algorithm = expected
+	}
+
+	// ED2K hashes have the same length as MD5 hashes.
+	if algorithm != expected && !(algorithm == ChecksumED2K && expected == ChecksumMD5) {
+		return nil, false
+	}
+
+	checksum := &Checksum{
+		Algorithm: algorithm,
+		Value:     strings.ToUpper(word),
+	}
+
+	return checksum, true
+}
+
+// setChecksum updates `*anime` with checksum, found as word in the name.
+func setChecksum(anime *Anime, checksum *Checksum, word segment) {
+	anime.Checksum = checksum
+	anime.Spans.Checksum = spansOf(word)
+
+	// Keep the original case for compatibility.
+	if checksum.Algorithm == ChecksumCRC32 {
+		anime.CRC32 = word.text[len(word.text)-len(checksum.Value):]
+	}
+}
+
+// isMixedChecksum returns true when checksum has both digits and letters, so
+// it can't be mistaken for a number (e.g. a date like "20231005") or a word
+// (e.g. "DEADBEEF") outside parens.
+func isMixedChecksum(checksum *Checksum) bool {
+	return strings.ContainsAny(checksum.Value, "0123456789") && strings.ContainsAny(checksum.Value, "ABCDEF")
+}
+
+// removeTrailingChecksum removes a checksum outside parens from the end of
+// chunk (e.g. "Title - 01 05BD70FE").
+//
+// Since words outside parens are more likely to be part of the title, the
+// checksum must contain both letters and digits.
+func removeTrailingChecksum(chunk segment, anime *Anime) segment {
+	words := splitWordSegments(trimSegment(chunk))
+	if len(words) < 2 {
+		return chunk
+	}
+
+	last := words[len(words)-1]
+
+	checksum, ok := parseChecksum(last.text)
+	if !ok || !isMixedChecksum(checksum) {
+		return chunk
+	}
+
+	setChecksum(anime, checksum, last)
+
+	return segment{
+		text:  strings.TrimRightFunc(chunk.text[:last.start-chunk.start], isWordSeparator),
+		start: chunk.start,
+	}
+}
+
+// isWordSeparator returns true when r separates words, as in `splitByWords`.
+func isWordSeparator(r rune) bool {
+	return unicode.IsSpace(r) || r == '_'
+}
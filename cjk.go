@@ -0,0 +1,153 @@
+package animenames
+
+import (
+	"strconv"
+)
+
+// kanjiDigits maps kanji numerals to their values.
+var kanjiDigits = map[rune]int{
+	'〇': 0,
+	'零': 0,
+	'一': 1,
+	'二': 2,
+	'三': 3,
+	'四': 4,
+	'五': 5,
+	'六': 6,
+	'七': 7,
+	'八': 8,
+	'九': 9,
+}
+
+// parseKanjiNumber returns the value of a number written with kanji numerals,
+// up to "九十九" (99).
+func parseKanjiNumber(s string) (int, bool) {
+	if s == "" {
+		return 0, false
+	}
+
+	total := 0
+	n := 0
+	seenTen := false
+
+	for _, r := range s {
+		if r == '十' {
+			if seenTen {
+				return 0, false
+			}
+
+			// "十" alone means 10, and "二十" means 20.
+			if n == 0 {
+				n = 1
+			}
+
+			total = n * 10
+			n = 0
+			seenTen = true
+
+			continue
+		}
+
+		d, ok := kanjiDigits[r]
+		if !ok {
+			return 0, false
+		}
+
+		n = n*10 + d
+	}
+
+	return total + n, true
+}
+
+// parseCJKNumber returns the value of a number written either with ASCII
+// digits or with kanji numerals.
+func parseCJKNumber(s string) (int, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+
+	return parseKanjiNumber(s)
+}
+
+// parseCJKMarkers updates `*anime` from the CJK episode and season markers in
+// word (e.g. "第01話" or "第二季"), and returns word without them.
+//
+// The boolean values report whether an episode (or batch) and a season were
+// found.
+func parseCJKMarkers(word segment, anime *Anime) (segment, bool, bool) {
+	foundEpisode := false
+	foundSeason := false
+
+	if m := regexpCJKBatch.FindStringSubmatchIndex(word.text); m != nil {
+		start, okStart := parseCJKNumber(word.text[m[2]:m[3]])
+		end, okEnd := parseCJKNumber(word.text[m[4]:m[5]])
+		if okStart && okEnd {
+			anime.Batch = &Batch{
+				Start: start,
+				End:   end,
+			}
+
+			var span Span
+			word, span = cutMarker(word, m)
+			anime.Spans.Batch = []Span{span}
+			foundEpisode = true
+		}
+	} else if m := regexpCJKEpisode.FindStringSubmatchIndex(word.text); m != nil {
+		if episode, ok := parseCJKNumber(word.text[m[2]:m[3]]); ok {
+			anime.Episode = episode
+
+			var span Span
+			word, span = cutMarker(word, m)
+			anime.Spans.Episode = []Span{span}
+			foundEpisode = true
+		}
+	} else if m := regexpCJKTotal.FindStringSubmatchIndex(word.text); m != nil {
+		if total, ok := parseCJKNumber(word.text[m[2]:m[3]]); ok {
+			anime.Batch = &Batch{
+				Start: 1,
+				End:   total,
+			}
+			anime.TotalEpisodes = total
+
+			var span Span
+			word, span = cutMarker(word, m)
+			anime.Spans.Batch = []Span{span}
+			anime.Spans.TotalEpisodes = []Span{span}
+			foundEpisode = true
+		}
+	}
+
+	if m := regexpCJKSeason.FindStringSubmatchIndex(word.text); m != nil {
+		if season, ok := parseCJKNumber(word.text[m[2]:m[3]]); ok {
+			anime.Season = season
+
+			var span Span
+			word, span = cutMarker(word, m)
+			anime.Spans.Season = []Span{span}
+			foundSeason = true
+		}
+	}
+
+	return word, foundEpisode, foundSeason
+}
+
+// cutMarker returns word without the marker at loc, and the span of the
+// marker.
+func cutMarker(word segment, loc []int) (segment, Span) {
+	span := Span{
+		Start: word.start + loc[0],
+		End:   word.start + loc[1],
+	}
+
+	start := word.start
+	if loc[0] == 0 {
+		start += loc[1]
+	}
+
+	rest := segment{
+		text:  word.text[:loc[0]] + word.text[loc[1]:],
+		start: start,
+	}
+
+	return rest, span
+}
@@ -0,0 +1,220 @@
+package animenames
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	ErrUnknownCategory  = errors.New("unknown keyword category")
+	ErrKeywordConflict  = errors.New("keyword assigned to more than one category")
+	ErrUnknownAliasWord = errors.New("alias of unknown keyword")
+	ErrAliasConflict    = errors.New("alias conflicts with a keyword or another alias")
+)
+
+// keywordCategories contains every category that dictionaries can use.
+var keywordCategories = map[KeywordCategory]bool{
+	CategoryResolution: true,
+	CategoryQuality:    true,
+	CategoryVideoCodec: true,
+	CategoryHDR:        true,
+	CategoryFrameRate:  true,
+	CategoryAudioCodec: true,
+	CategoryExtension:  true,
+	CategoryProperty:   true,
+	CategoryPlatform:   true,
+	CategoryRevision:   true,
+}
+
+// Dictionary contains keywords and aliases that can be added to a parser with
+// `WithDictionary`.
+//
+// A dictionary in JSON looks like:
+//
+//	{
+//		"replace": false,
+//		"keywords": {
+//			"video_codec": ["av1", "vp9"],
+//			"audio_codec": ["dts"]
+//		},
+//		"aliases": {
+//			"x266": "vvc"
+//		}
+//	}
+//
+// And the same dictionary in YAML:
+//
+//	replace: false
+//	keywords:
+//	  video_codec: [av1, vp9]
+//	  audio_codec: [dts]
+//	aliases:
+//	  x266: vvc
+//
+// Dictionaries built in other ways must be checked with `Validate` before
+// using them.
+type Dictionary struct {
+	// Replace makes the dictionary replace the built-in keywords and
+	// aliases, instead of being merged with them.
+	Replace bool `json:"replace" yaml:"replace"`
+
+	Keywords map[KeywordCategory][]string `json:"keywords" yaml:"keywords"`
+
+	// Aliases maps each alias to the keyword it stands for (e.g. "bluray"
+	// to "bd").
+	Aliases map[string]string `json:"aliases" yaml:"aliases"`
+}
+
+// LoadDictionary reads a JSON dictionary from r, and validates it.
+func LoadDictionary(r io.Reader) (*Dictionary, error) {
+	var d Dictionary
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&d); err != nil {
+		return nil, fmt.Errorf("could not decode dictionary: %w", err)
+	}
+
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// LoadDictionaryYAML reads a YAML dictionary from r, and validates it.
+func LoadDictionaryYAML(r io.Reader) (*Dictionary, error) {
+	var d Dictionary
+
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(&d); err != nil {
+		return nil, fmt.Errorf("could not decode dictionary: %w", err)
+	}
+
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &d, nil
+}
+
+// LoadDictionaryFile reads a dictionary from the file at path, and validates
+// it.
+//
+// Files ending in ".yaml" or ".yml" are read as YAML, and any other file as
+// JSON.
+func LoadDictionaryFile(path string) (*Dictionary, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	load := LoadDictionary
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		load = LoadDictionaryYAML
+	}
+
+	d, err := load(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return d, nil
+}
+
+// Validate returns an error when the dictionary uses an unknown category,
+// assigns a keyword to more than one category, has aliases of unknown
+// keywords, has aliases that are also keywords, or changes an alias to a
+// keyword of another category.
+//
+// Unless `d.Replace` is true, keywords and aliases are also compared against
+// the built-in ones.
+func (d *Dictionary) Validate() error {
+	keywords := map[string]KeywordCategory{}
+	aliases := map[string]string{}
+	if !d.Replace {
+		keywords = defaultKeywords()
+		aliases = defaultAliases
+	}
+
+	categories := make([]string, 0, len(d.Keywords))
+	for category := range d.Keywords {
+		categories = append(categories, string(category))
+	}
+
+	// Sort categories so errors are always the same for the same
+	// dictionary.
+	sort.Strings(categories)
+
+	for _, c := range categories {
+		category := KeywordCategory(c)
+		if !keywordCategories[category] {
+			return fmt.Errorf("%w: %#v", ErrUnknownCategory, c)
+		}
+
+		for _, keyword := range d.Keywords[category] {
+			keyword = strings.ToLower(keyword)
+
+			if other, ok := keywords[keyword]; ok && other != category {
+				return fmt.Errorf("%w: %#v is in %#v and %#v", ErrKeywordConflict, keyword, string(other), c)
+			}
+
+			keywords[keyword] = category
+		}
+	}
+
+	dictionaryAliases := make([]string, 0, len(d.Aliases))
+	for alias := range d.Aliases {
+		dictionaryAliases = append(dictionaryAliases, alias)
+	}
+
+	sort.Strings(dictionaryAliases)
+
+	for _, alias := range dictionaryAliases {
+		keyword := strings.ToLower(d.Aliases[alias])
+
+		category, ok := keywords[keyword]
+		if !ok {
+			return fmt.Errorf("%w: %#v is an alias of %#v", ErrUnknownAliasWord, alias, keyword)
+		}
+
+		lalias := strings.ToLower(alias)
+
+		if other, ok := keywords[lalias]; ok {
+			return fmt.Errorf("%w: %#v is an alias of %#v, but it's also a keyword in %#v", ErrAliasConflict, alias, keyword, string(other))
+		}
+
+		if old, ok := aliases[lalias]; ok && keywords[old] != category {
+			return fmt.Errorf("%w: %#v is an alias of %#v in %#v, and of %#v in %#v", ErrAliasConflict, alias, old, string(keywords[old]), keyword, string(category))
+		}
+	}
+
+	return nil
+}
+
+// apply adds the keywords and aliases from d to p.
+func (d *Dictionary) apply(p *Parser) {
+	if d.Replace {
+		p.keywords = map[string]KeywordCategory{}
+		p.aliases = map[string]string{}
+	}
+
+	for category, keywords := range d.Keywords {
+		WithKeywords(category, keywords...)(p)
+	}
+
+	WithAliases(d.Aliases)(p)
+}
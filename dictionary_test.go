@@ -0,0 +1,125 @@
+package animenames_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/c032/go-animenames"
+)
+
+func TestLoadDictionary(t *testing.T) {
+	d, err := animenames.LoadDictionary(strings.NewReader(`{
+		"keywords": {
+			"video_codec": ["AV1"]
+		},
+		"aliases": {
+			"av01": "av1"
+		}
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parser := animenames.NewParser(animenames.WithDictionary(d))
+
+	for _, name := range []string{"Title - 01 [AV1]", "Title - 01 [AV01]"} {
+		gotAnime, err := parser.Parse(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if gotAnime.Group != "" {
+			t.Errorf("parser.Parse(%#v).Group = %#v; expected %#v", name, gotAnime.Group, "")
+		}
+
+		if gotAnime.Episode != 1 {
+			t.Errorf("parser.Parse(%#v).Episode = %#v; expected %#v", name, gotAnime.Episode, 1)
+		}
+	}
+}
+
+func TestLoadDictionaryYAML(t *testing.T) {
+	d, err := animenames.LoadDictionaryYAML(strings.NewReader(`
+keywords:
+  video_codec: [AV1]
+aliases:
+  av01: av1
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parser := animenames.NewParser(animenames.WithDictionary(d))
+
+	const name = "Title - 01 [AV01]"
+
+	gotAnime, err := parser.Parse(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotAnime.Group != "" {
+		t.Errorf("parser.Parse(%#v).Group = %#v; expected %#v", name, gotAnime.Group, "")
+	}
+
+	if gotAnime.Episode != 1 {
+		t.Errorf("parser.Parse(%#v).Episode = %#v; expected %#v", name, gotAnime.Episode, 1)
+	}
+
+	_, err = animenames.LoadDictionaryYAML(strings.NewReader("keywords:\n  video_codec: [flac]\n"))
+	if !errors.Is(err, animenames.ErrKeywordConflict) {
+		t.Errorf("animenames.LoadDictionaryYAML() error = %v; expected %v", err, animenames.ErrKeywordConflict)
+	}
+}
+
+func TestLoadDictionaryFile(t *testing.T) {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"keywords.json": `{"keywords": {"video_codec": ["av1"]}}`,
+		"keywords.yaml": "keywords:\n  video_codec: [av1]\n",
+		"keywords.yml":  "keywords: {video_codec: [av1]}\n",
+	}
+
+	for file, document := range files {
+		path := filepath.Join(dir, file)
+		if err := os.WriteFile(path, []byte(document), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		d, err := animenames.LoadDictionaryFile(path)
+		if err != nil {
+			t.Errorf("animenames.LoadDictionaryFile(%#v) error = %v; expected nil", file, err)
+
+			continue
+		}
+
+		if len(d.Keywords[animenames.CategoryVideoCodec]) != 1 {
+			t.Errorf("animenames.LoadDictionaryFile(%#v).Keywords = %#v; expected one video codec", file, d.Keywords)
+		}
+	}
+}
+
+func TestLoadDictionaryErrors(t *testing.T) {
+	tests := map[string]error{
+		`{"keywords": {"studio": ["mappa"]}}`:                                                   animenames.ErrUnknownCategory,
+		`{"keywords": {"video_codec": ["flac"]}}`:                                               animenames.ErrKeywordConflict,
+		`{"keywords": {"quality": ["av1"], "video_codec": ["AV1"]}}`:                            animenames.ErrKeywordConflict,
+		`{"aliases": {"av01": "av1"}}`:                                                          animenames.ErrUnknownAliasWord,
+		`{"aliases": {"flac": "aac"}}`:                                                          animenames.ErrAliasConflict,
+		`{"keywords": {"video_codec": ["av1", "av01"]}, "aliases": {"AV01": "av1"}}`:            animenames.ErrAliasConflict,
+		`{"aliases": {"x264": "aac"}}`:                                                          animenames.ErrAliasConflict,
+		`{"aliases": {"x264": "hevc"}}`:                                                         nil,
+		`{"replace": true, "keywords": {"resolution": ["1080p"]}, "aliases": {"fhd": "1080p"}}`: nil,
+	}
+
+	for document, expectedErr := range tests {
+		_, err := animenames.LoadDictionary(strings.NewReader(document))
+		if !errors.Is(err, expectedErr) {
+			t.Errorf("animenames.LoadDictionary(%#v) error = %v; expected %v", document, err, expectedErr)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package animenames
+
+import (
+	"regexp"
+	"strings"
+)
+
+// regexpEmptyBrackets matches brackets left empty after removing claimed
+// tokens.
+var regexpEmptyBrackets = regexp.MustCompile(`[\[({【［（｛]\s*[\])}】］）｝]`)
+
+// Extractor finds information that the built-in rules don't know about (e.g.
+// studio names).
+type Extractor interface {
+	// Extract updates `*anime` from tokens, and returns the tokens it used.
+	//
+	// Returned tokens are claimed: they're not given to extractors with
+	// lower priority, and they're removed from the name before the built-in
+	// rules run.
+	Extract(tokens []Token, anime *Anime) []Token
+}
+
+// ExtractorFunc is an `Extractor` implemented by a function.
+type ExtractorFunc func(tokens []Token, anime *Anime) []Token
+
+// Extract calls `f(tokens, anime)`.
+func (f ExtractorFunc) Extract(tokens []Token, anime *Anime) []Token {
+	return f(tokens, anime)
+}
+
+type registeredExtractor struct {
+	extractor Extractor
+	priority  int
+}
+
+// runExtractors runs the extractors of the parser, and returns name with the
+// claimed tokens replaced by spaces, so offsets don't change.
+//
+// Fields set by an extractor get the spans of the tokens it claimed.
+func (p *Parser) runExtractors(name string, anime *Anime) string {
+	if len(p.extractors) == 0 {
+		return name
+	}
+
+	tokens := p.Tokenize(name)
+	claimed := make([]bool, len(tokens))
+
+	for _, e := range p.extractors {
+		available := make([]Token, 0, len(tokens))
+		indexes := make([]int, 0, len(tokens))
+
+		for i, token := range tokens {
+			if !claimed[i] {
+				available = append(available, token)
+				indexes = append(indexes, i)
+			}
+		}
+
+		fields := make(map[string]string, len(anime.Fields))
+		for key, value := range anime.Fields {
+			fields[key] = value
+		}
+
+		spans := make([]Span, 0)
+
+		for _, token := range e.extractor.Extract(available, anime) {
+			for j, t := range available {
+				if t.Start == token.Start && t.End == token.End {
+					claimed[indexes[j]] = true
+					spans = append(spans, Span{Start: t.Start, End: t.End})
+				}
+			}
+		}
+
+		for key, value := range anime.Fields {
+			if old, ok := fields[key]; ok && old == value {
+				continue
+			}
+
+			if anime.Spans.Fields == nil {
+				anime.Spans.Fields = map[string][]Span{}
+			}
+
+			anime.Spans.Fields[key] = spans
+		}
+	}
+
+	b := []byte(name)
+	for i, token := range tokens {
+		if !claimed[i] {
+			continue
+		}
+
+		for j := token.Start; j < token.End; j++ {
+			b[j] = ' '
+		}
+	}
+
+	return regexpEmptyBrackets.ReplaceAllStringFunc(string(b), func(s string) string {
+		return strings.Repeat(" ", len(s))
+	})
+}
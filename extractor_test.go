@@ -0,0 +1,80 @@
+package animenames_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/c032/go-animenames"
+)
+
+// studioExtractor claims the tokens that are one of words, and sets the
+// "studio" field to studio.
+func studioExtractor(studio string, words ...string) animenames.Extractor {
+	return animenames.ExtractorFunc(func(tokens []animenames.Token, anime *animenames.Anime) []animenames.Token {
+		claimed := make([]animenames.Token, 0)
+
+		for _, token := range tokens {
+			for _, word := range words {
+				if strings.EqualFold(token.Text, word) {
+					anime.SetField("studio", studio)
+					claimed = append(claimed, token)
+				}
+			}
+		}
+
+		return claimed
+	})
+}
+
+func TestExtractor(t *testing.T) {
+	parser := animenames.NewParser(
+		animenames.WithExtractor(0, studioExtractor("MAPPA", "MAPPA")),
+	)
+
+	const name = "[Group] Jujutsu Kaisen - 01 [MAPPA][1080p]"
+
+	gotAnime, err := parser.Parse(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotAnime.Fields["studio"] != "MAPPA" {
+		t.Errorf("parser.Parse(%#v).Fields[%#v] = %#v; expected %#v", name, "studio", gotAnime.Fields["studio"], "MAPPA")
+	}
+
+	if gotAnime.Title != "Jujutsu Kaisen" {
+		t.Errorf("parser.Parse(%#v).Title = %#v; expected %#v", name, gotAnime.Title, "Jujutsu Kaisen")
+	}
+
+	if gotAnime.Group != "Group" {
+		t.Errorf("parser.Parse(%#v).Group = %#v; expected %#v", name, gotAnime.Group, "Group")
+	}
+
+	if gotAnime.Episode != 1 {
+		t.Errorf("parser.Parse(%#v).Episode = %#v; expected %#v", name, gotAnime.Episode, 1)
+	}
+
+	if gotAnime.Resolution != "1080p" {
+		t.Errorf("parser.Parse(%#v).Resolution = %#v; expected %#v", name, gotAnime.Resolution, "1080p")
+	}
+}
+
+func TestExtractorPriority(t *testing.T) {
+	parser := animenames.NewParser(
+		animenames.WithExtractor(0, studioExtractor("Kyoto", "Kyoto")),
+		animenames.WithExtractor(10, studioExtractor("Kyoto Animation", "KyoAni", "Kyoto")),
+	)
+
+	const name = "[Group] Hyouka - 01 [Kyoto]"
+
+	gotAnime, err := parser.Parse(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The extractor with the lowest priority doesn't see the token claimed
+	// by the other one, so it doesn't overwrite the field.
+	if gotAnime.Fields["studio"] != "Kyoto Animation" {
+		t.Errorf("parser.Parse(%#v).Fields[%#v] = %#v; expected %#v", name, "studio", gotAnime.Fields["studio"], "Kyoto Animation")
+	}
+}
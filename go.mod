@@ -2,4 +2,7 @@ module github.com/c032/go-animenames
 
 go 1.18
 
-require github.com/c032/go-textutil v0.0.0-20220907062543-7021143cbf1c
+require (
+	github.com/c032/go-textutil v0.0.0-20220907062543-7021143cbf1c
+	gopkg.in/yaml.v3 v3.0.1
+)
@@ -0,0 +1,204 @@
+package animenames
+
+import (
+	"strings"
+	"sync"
+)
+
+// GroupType describes the kind of releases made by a group.
+type GroupType int
+
+const (
+	GroupTypeUnknown GroupType = iota
+	GroupTypeFansub
+	GroupTypeRaw
+)
+
+// GroupInfo describes a release group.
+type GroupInfo struct {
+	Name    string
+	Aliases []string // e.g. former names, like "HorribleSubs" for "SubsPlease"
+	Type    GroupType
+}
+
+// Registry contains known release groups.
+//
+// It's used to decide whether a group name like "UTW-Mazui-MK" is a
+// collaboration between several groups, or a single group with a dash in its
+// name (e.g. "project-gxs"), and whether a lone word is a group or a title.
+//
+// It's safe for concurrent use.
+type Registry struct {
+	mu sync.RWMutex
+
+	// groups is indexed by lowercase name and lowercase aliases.
+	groups map[string]GroupInfo
+}
+
+// NewRegistry returns a registry containing groups.
+func NewRegistry(groups ...GroupInfo) *Registry {
+	r := &Registry{
+		groups: map[string]GroupInfo{},
+	}
+
+	for _, group := range groups {
+		r.Add(group)
+	}
+
+	return r
+}
+
+// Add adds group to the registry, replacing any group with the same name or
+// alias.
+func (r *Registry) Add(group GroupInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Remove the names and aliases of the groups being replaced, so they
+	// don't keep pointing to the old group.
+	for _, key := range append([]string{group.Name}, group.Aliases...) {
+		old, ok := r.groups[strings.ToLower(key)]
+		if !ok {
+			continue
+		}
+
+		delete(r.groups, strings.ToLower(old.Name))
+		for _, alias := range old.Aliases {
+			delete(r.groups, strings.ToLower(alias))
+		}
+	}
+
+	r.groups[strings.ToLower(group.Name)] = group
+	for _, alias := range group.Aliases {
+		r.groups[strings.ToLower(alias)] = group
+	}
+}
+
+// Lookup returns the group with the given name or alias.
+func (r *Registry) Lookup(name string) (GroupInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	group, ok := r.groups[strings.ToLower(strings.TrimSpace(name))]
+
+	return group, ok
+}
+
+// DefaultRegistry contains the built-in release groups.
+//
+// It's used by `Parse` and by parsers created without `WithRegistry`, so
+// groups added to it are recognized by them.
+var DefaultRegistry = NewRegistry(defaultGroups...)
+
+var defaultGroups = []GroupInfo{
+	{Name: "ASW", Type: GroupTypeFansub},
+	{Name: "Coalgirls", Type: GroupTypeFansub},
+	{Name: "Commie", Type: GroupTypeFansub},
+	{Name: "DameDesuYo", Type: GroupTypeFansub},
+	{Name: "DeadFish", Type: GroupTypeFansub},
+	{Name: "Doki", Type: GroupTypeFansub},
+	{Name: "EMBER", Type: GroupTypeFansub},
+	{Name: "Erai-raws"},
+	{Name: "FFF", Type: GroupTypeFansub},
+	{Name: "GoodJob", Type: GroupTypeFansub},
+	{Name: "Judas", Type: GroupTypeFansub},
+	{Name: "Kaylith", Type: GroupTypeFansub},
+	{Name: "Leopard-Raws", Type: GroupTypeRaw},
+	{Name: "MK", Type: GroupTypeFansub},
+	{Name: "MTBB", Type: GroupTypeFansub},
+	{Name: "Mazui", Type: GroupTypeFansub},
+	{Name: "Ohys-Raws", Type: GroupTypeRaw},
+	{Name: "SubsPlease", Aliases: []string{"HorribleSubs"}, Type: GroupTypeFansub},
+	{Name: "Thora", Type: GroupTypeFansub},
+	{Name: "UTW", Type: GroupTypeFansub},
+	{Name: "Underwater", Type: GroupTypeFansub},
+	{Name: "Vivid", Type: GroupTypeFansub},
+	{Name: "WhyNot", Type: GroupTypeFansub},
+	{Name: "gg", Type: GroupTypeFansub},
+}
+
+// isKnownGroup returns true when group is a known release group and false
+// otherwise.
+func (p *Parser) isKnownGroup(group string) bool {
+	_, ok := p.registry.Lookup(group)
+
+	return ok
+}
+
+// splitGroup returns the names of the groups that collaborated in a release.
+//
+// Groups separated by "&", "+" or "," are always split. Groups separated by
+// "-" are only split when all of them are known groups.
+func (p *Parser) splitGroup(group string) []string {
+	groups := make([]string, 0)
+
+	for _, part := range regexpGroupSplit.Split(group, -1) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if subgroups := strings.Split(part, "-"); len(subgroups) > 1 && p.allKnownGroups(subgroups) {
+			groups = append(groups, subgroups...)
+
+			continue
+		}
+
+		groups = append(groups, part)
+	}
+
+	return groups
+}
+
+// isRawGroup returns true when group only makes releases without subtitles,
+// either because the registry says so or because its name ends with "-Raws"
+// (e.g. "Ohys-Raws").
+func (p *Parser) isRawGroup(group string) bool {
+	if info, ok := p.registry.Lookup(group); ok && info.Type != GroupTypeUnknown {
+		return info.Type == GroupTypeRaw
+	}
+
+	return regexpRawGroup.MatchString(group)
+}
+
+// splitGroupSuffix splits a word like "H264-GROUP" at the last dash, and
+// returns the text before the dash and the group.
+//
+// To avoid confusing hyphenated words with groups (e.g. "Umaru-chan"), the
+// dash must be preceded by nothing, a keyword or a season number. Episode
+// numbers are rejected too, because "01-END" is an episode followed by a
+// word, not a group.
+func (p *Parser) splitGroupSuffix(word string) (string, string, bool) {
+	i := strings.LastIndex(word, "-")
+	if i == -1 {
+		return word, "", false
+	}
+
+	prefix := word[:i]
+	suffix := word[i+1:]
+
+	if suffix == "" || p.isKeyword(strings.ToLower(suffix)) || regexpEpisode.MatchString(suffix) {
+		return word, "", false
+	}
+
+	if prefix != "" &&
+		!p.isKeyword(strings.ToLower(prefix)) &&
+		!regexpSeason.MatchString(prefix) &&
+		!regexpSeasonEpisode.MatchString(prefix) {
+		return word, "", false
+	}
+
+	return prefix, suffix, true
+}
+
+// allKnownGroups returns true when every element of groups is a known release
+// group.
+func (p *Parser) allKnownGroups(groups []string) bool {
+	for _, group := range groups {
+		if !p.isKnownGroup(group) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,87 @@
+package animenames_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/c032/go-animenames"
+)
+
+func TestRegistry(t *testing.T) {
+	r := animenames.NewRegistry(animenames.GroupInfo{
+		Name:    "SubsPlease",
+		Aliases: []string{"HorribleSubs"},
+		Type:    animenames.GroupTypeFansub,
+	})
+
+	group, ok := r.Lookup("horriblesubs")
+	if !ok {
+		t.Fatalf("r.Lookup(%#v) did not find a group", "horriblesubs")
+	}
+
+	if group.Name != "SubsPlease" {
+		t.Errorf("r.Lookup(%#v).Name = %#v; expected %#v", "horriblesubs", group.Name, "SubsPlease")
+	}
+
+	if _, ok := r.Lookup("Ohys-Raws"); ok {
+		t.Errorf("r.Lookup(%#v) found a group before it was added", "Ohys-Raws")
+	}
+
+	r.Add(animenames.GroupInfo{
+		Name: "Ohys-Raws",
+		Type: animenames.GroupTypeRaw,
+	})
+
+	group, ok = r.Lookup("Ohys-Raws")
+	if !ok {
+		t.Fatalf("r.Lookup(%#v) did not find a group", "Ohys-Raws")
+	}
+
+	if group.Type != animenames.GroupTypeRaw {
+		t.Errorf("r.Lookup(%#v).Type = %#v; expected %#v", "Ohys-Raws", group.Type, animenames.GroupTypeRaw)
+	}
+}
+
+func TestRegistryReplace(t *testing.T) {
+	r := animenames.NewRegistry(animenames.GroupInfo{
+		Name:    "SubsPlease",
+		Aliases: []string{"HorribleSubs"},
+		Type:    animenames.GroupTypeFansub,
+	})
+
+	r.Add(animenames.GroupInfo{
+		Name: "SubsPlease",
+		Type: animenames.GroupTypeFansub,
+	})
+
+	if group, ok := r.Lookup("HorribleSubs"); ok {
+		t.Errorf("r.Lookup(%#v) = %#v; expected no group", "HorribleSubs", group)
+	}
+
+	if _, ok := r.Lookup("SubsPlease"); !ok {
+		t.Errorf("r.Lookup(%#v) did not find a group", "SubsPlease")
+	}
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	if _, ok := animenames.DefaultRegistry.Lookup("erai-raws"); !ok {
+		t.Errorf("animenames.DefaultRegistry.Lookup(%#v) did not find a group", "erai-raws")
+	}
+}
+
+func TestDefaultRegistryAdd(t *testing.T) {
+	const name = "Title - 01 (Foo-Bar)"
+
+	animenames.DefaultRegistry.Add(animenames.GroupInfo{Name: "Foo", Type: animenames.GroupTypeFansub})
+	animenames.DefaultRegistry.Add(animenames.GroupInfo{Name: "Bar", Type: animenames.GroupTypeFansub})
+
+	gotAnime, err := animenames.Parse(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedGroups := []string{"Foo", "Bar"}
+	if !reflect.DeepEqual(gotAnime.Groups, expectedGroups) {
+		t.Errorf("animenames.Parse(%#v).Groups = %#v; expected %#v", name, gotAnime.Groups, expectedGroups)
+	}
+}
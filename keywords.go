@@ -1,20 +1,43 @@
 package animenames
 
 import (
+	"strconv"
 	"strings"
 )
 
-var aliases = map[string]string{
-	"blu-ray": "bd",
-	"bluray":  "bd",
-	"h.264":   "h264",
-	"x264":    "h264",
-	"sps":     "specials",
-	"special": "specials",
+var defaultAliases = map[string]string{
+	"blu-ray":     "bd",
+	"bluray":      "bd",
+	"h.264":       "h264",
+	"x264":        "h264",
+	"h.265":       "hevc",
+	"h265":        "hevc",
+	"x265":        "hevc",
+	"sps":         "specials",
+	"special":     "specials",
+	"webdl":       "web-dl",
+	"amazon":      "amzn",
+	"bilibili":    "b-global",
+	"crunchyroll": "cr",
+	"disney+":     "dsnp",
+	"hardsubs":    "hardsub",
+	"softsubs":    "softsub",
+	"raws":        "raw",
+	"hidive":      "hidi",
+	"netflix":     "nf",
+	"fix":         "fixed",
+	"reencode":    "re-encode",
+	"re-rip":      "rerip",
+	"re-upload":   "reupload",
+	"4k":          "2160p",
+	"uhd":         "2160p",
+	"dovi":        "dv",
+	"hdr10plus":   "hdr10+",
 }
 
 var resolutions = []string{
 	"1080p",
+	"2160p",
 	"360p",
 	"480p",
 	"720p",
@@ -26,6 +49,9 @@ var quality = []string{
 	"bd",
 	"dvd",
 	"tv",
+	"web",
+	"web-dl",
+	"webrip",
 }
 
 var videoCodecs = []string{
@@ -33,6 +59,26 @@ var videoCodecs = []string{
 	"hevc",
 }
 
+var hdrFormats = []string{
+	"dv",
+	"hdr",
+	"hdr10",
+	"hdr10+",
+	"hlg",
+	"sdr",
+}
+
+// frameRates contains frame rates commonly written without "fps" (e.g.
+// "23.976"), besides variable and constant frame rate markers.
+var frameRates = []string{
+	"119.88",
+	"23.976",
+	"29.97",
+	"59.94",
+	"cfr",
+	"vfr",
+}
+
 var audioCodecs = []string{
 	"aac",
 	"ac3",
@@ -52,46 +98,274 @@ var otherProperties = []string{
 	"dual",
 	"dub",
 	"english",
+	"hardsub",
+	"raw",
 	"simuldub",
+	"softsub",
 	"uncensored",
 	"specials",
+	"sub",
 }
 
-var keywordsMap = map[string]bool{}
+var platforms = []string{
+	"amzn",
+	"b-global",
+	"cr",
+	"dsnp",
+	"hidi",
+	"nf",
+}
+
+// ambiguousKeywords contains keywords that are also common words in titles
+// (e.g. "Web" in "Web Ghosts").
+//
+// Outside parens, they're only keywords in scene-style names, or when they're
+// next to other keywords (e.g. "WEB 1080p").
+var ambiguousKeywords = map[string]bool{
+	"cr":      true,
+	"dovi":    true,
+	"dv":      true,
+	"nf":      true,
+	"raw":     true,
+	"raws":    true,
+	"special": true,
+	"sub":     true,
+	"web":     true,
+}
+
+// defaultPlatformNames maps platform keywords to the name stored in
+// `Anime.Platform`.
+//
+// Platforms added with `WithKeywords` are named as they're written there.
+var defaultPlatformNames = map[string]string{
+	"amzn":     "Amazon",
+	"b-global": "Bilibili",
+	"cr":       "Crunchyroll",
+	"dsnp":     "Disney+",
+	"hidi":     "HIDIVE",
+	"nf":       "Netflix",
+}
+
+// defaultSubtitleLanguages maps lowercase subtitle language tags, as found in
+// names where every field is inside parens, to language codes.
+//
+// They're not keywords, because tags like "GB" are also used as group names.
+var defaultSubtitleLanguages = map[string]string{
+	"big5": "zh-Hant",
+	"chs":  "zh-Hans",
+	"cht":  "zh-Hant",
+	"gb":   "zh-Hans",
+	"sc":   "zh-Hans",
+	"tc":   "zh-Hant",
+	"简中":   "zh-Hans",
+	"简体":   "zh-Hans",
+	"简日":   "zh-Hans",
+	"繁中":   "zh-Hant",
+	"繁体":   "zh-Hant",
+	"繁體":   "zh-Hant",
+	"繁日":   "zh-Hant",
+}
+
+// defaultHDRFormats maps HDR keywords to the value stored in `Anime.HDR`.
+//
+// HDR keywords added with `WithKeywords` are taken as `HDR10`, unless they're
+// aliases of one of these.
+var defaultHDRFormats = map[string]HDRFormat{
+	"dv":     HDRDolbyVision,
+	"hdr":    HDR10,
+	"hdr10":  HDR10,
+	"hdr10+": HDR10Plus,
+	"hlg":    HDRHLG,
+	"sdr":    HDRNone,
+}
+
+// defaultAiringSeasons maps lowercase airing season names to their normalized
+// form.
+var defaultAiringSeasons = map[string]string{
+	"winter": "Winter",
+	"spring": "Spring",
+	"summer": "Summer",
+	"fall":   "Fall",
+	"autumn": "Fall",
+}
+
+// KeywordCategory is the kind of information described by a keyword.
+type KeywordCategory string
+
+const (
+	CategoryResolution KeywordCategory = "resolution"
+	CategoryQuality    KeywordCategory = "quality"
+	CategoryVideoCodec KeywordCategory = "video_codec"
+	CategoryHDR        KeywordCategory = "hdr"
+	CategoryFrameRate  KeywordCategory = "frame_rate"
+	CategoryAudioCodec KeywordCategory = "audio_codec"
+	CategoryExtension  KeywordCategory = "extension"
+	CategoryProperty   KeywordCategory = "property"
+	CategoryPlatform   KeywordCategory = "platform"
+	CategoryRevision   KeywordCategory = "revision"
+)
 
-func init() {
-	lists := [][]string{
-		resolutions,
-		quality,
-		videoCodecs,
-		audioCodecs,
-		extensions,
-		otherProperties,
+// defaultKeywords returns the built-in keywords, indexed by keyword.
+func defaultKeywords() map[string]KeywordCategory {
+	lists := map[KeywordCategory][]string{
+		CategoryResolution: resolutions,
+		CategoryQuality:    quality,
+		CategoryVideoCodec: videoCodecs,
+		CategoryHDR:        hdrFormats,
+		CategoryFrameRate:  frameRates,
+		CategoryAudioCodec: audioCodecs,
+		CategoryExtension:  extensions,
+		CategoryProperty:   otherProperties,
+		CategoryPlatform:   platforms,
+		CategoryRevision:   revisionMarkers,
 	}
 
-	for _, list := range lists {
+	keywords := map[string]KeywordCategory{}
+
+	for category, list := range lists {
 		for _, keyword := range list {
-			keywordsMap[keyword] = true
+			keywords[keyword] = category
 		}
 	}
+
+	return keywords
 }
 
 // isKeyword returns true when word is a known keyword and false otherwise.
-func isKeyword(word string) bool {
-	if _, ok := aliases[word]; ok {
+//
+// Words made of keywords joined by dots (e.g. "amzn.web-dl") are also
+// considered keywords.
+func (p *Parser) isKeyword(word string) bool {
+	if p.isSingleKeyword(word) {
 		return true
 	}
 
-	return keywordsMap[word]
+	if !strings.Contains(word, ".") {
+		return false
+	}
+
+	for _, part := range strings.Split(word, ".") {
+		if part == "" || !p.isSingleKeyword(part) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isSingleKeyword returns true when word is a known keyword by itself, and
+// false otherwise.
+func (p *Parser) isSingleKeyword(word string) bool {
+	if _, ok := p.aliases[word]; ok {
+		return true
+	}
+
+	if _, ok := p.keywords[word]; ok {
+		return true
+	}
+
+	// Frame rates like "60fps".
+	if regexpFrameRate.MatchString(word) {
+		return true
+	}
+
+	// Versions like "v2".
+	if regexpVersion.MatchString(word) {
+		return true
+	}
+
+	// Sizes, durations and dates.
+	return isTag(word)
+}
+
+// isResolution returns true when word is a known resolution keyword and false
+// otherwise.
+func (p *Parser) isResolution(word string) bool {
+	return p.keywords[word] == CategoryResolution
+}
+
+// isFrameRate returns true when word is a known frame rate keyword and false
+// otherwise.
+func (p *Parser) isFrameRate(word string) bool {
+	return p.keywords[word] == CategoryFrameRate
+}
+
+// isExtension returns true when word is a known file extension and false
+// otherwise.
+func (p *Parser) isExtension(word string) bool {
+	return p.keywords[word] == CategoryExtension
+}
+
+// trimExtension returns name without its file extension, if it has a known
+// one.
+func (p *Parser) trimExtension(name string) string {
+	i := strings.LastIndex(name, ".")
+	if i == -1 || !p.isExtension(strings.ToLower(name[i+1:])) {
+		return name
+	}
+
+	return name[:i]
+}
+
+// splitKeywordsOutsideParens returns the words outside parens that are
+// keywords, and the rest of the words.
+//
+// In scene-style names (when scene is true) every keyword is recognized.
+// Otherwise, ambiguous keywords must be next to another keyword, so they're
+// not taken from titles.
+func (p *Parser) splitKeywordsOutsideParens(words []segment, scene bool) ([]segment, []segment) {
+	texts := segmentTexts(words)
+
+	isKeyword := make([]bool, len(words))
+	for i, word := range texts {
+		lword := strings.ToLower(word)
+
+		// Sizes and durations need an explicit unit outside parens.
+		isKeyword[i] = p.isKeyword(lword) && (!isTag(lword) || isUnambiguousTag(lword))
+
+		if isKeyword[i] && p.isRevisionMarker(p.normalizeKeyword(lword)) {
+			isKeyword[i] = isAfterEpisode(texts, i)
+		}
+	}
+
+	keywords := make([]segment, 0)
+	rest := make([]segment, 0)
+
+	for i, word := range words {
+		lword := strings.ToLower(word.text)
+
+		ok := isKeyword[i]
+		if ok && !scene && ambiguousKeywords[lword] {
+			ok = (i > 0 && isKeyword[i-1] && !ambiguousKeywords[strings.ToLower(texts[i-1])]) ||
+				(i+1 < len(words) && isKeyword[i+1] && !ambiguousKeywords[strings.ToLower(texts[i+1])])
+		}
+
+		if ok {
+			keywords = append(keywords, word)
+		} else {
+			rest = append(rest, word)
+		}
+	}
+
+	return keywords, rest
+}
+
+// normalizeKeyword returns the keyword that `word` is an alias of, or `word`
+// itself if it's not an alias.
+func (p *Parser) normalizeKeyword(word string) string {
+	if keyword, ok := p.aliases[word]; ok {
+		return keyword
+	}
+
+	return word
 }
 
-// removeKeywords returns a slice with words from text, without any keywords.
-func removeKeywords(text string) []string {
-	words := make([]string, 0)
-	allWords := splitByWords(text)
+// removeKeywords returns a slice with the words that aren't keywords.
+func (p *Parser) removeKeywords(allWords []segment) []segment {
+	words := make([]segment, 0)
 
 	for _, word := range allWords {
-		if isKeyword(strings.ToLower(word)) {
+		if p.isKeyword(strings.ToLower(word.text)) {
 			continue
 		}
 		words = append(words, word)
@@ -100,24 +374,180 @@ func removeKeywords(text string) []string {
 	return words
 }
 
+// parseAiringSeason returns the normalized airing season and the year from a
+// pair of words like "Winter 2023".
+func (p *Parser) parseAiringSeason(season, year string) (string, int, bool) {
+	normalized, ok := p.airingSeasons[strings.ToLower(season)]
+	if !ok {
+		return "", 0, false
+	}
+
+	if !regexpYear.MatchString(year) {
+		return "", 0, false
+	}
+
+	y, err := strconv.Atoi(year)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return normalized, y, true
+}
+
 // parseKeywords tries to extract information from the keywords present in the
 // chunk, and updates `*anime`.
-func parseKeywords(chunk string, anime *Anime) {
-	words := splitByWords(chunk)
+func (p *Parser) parseKeywords(chunk segment, anime *Anime) {
+	words := splitWordSegments(chunk)
 
 	for _, word := range words {
-		lword := strings.ToLower(word)
+		lword := strings.ToLower(word.text)
 
-		if lword == "bd" || lword == "bdrip" || lword == "blu-ray" || lword == "bluray" {
-			anime.IsBD = true
+		// Keywords joined by dots (e.g. "AMZN.WEB-DL").
+		if strings.Contains(lword, ".") && !p.isSingleKeyword(lword) {
+			start := word.start
+			for _, part := range strings.Split(lword, ".") {
+				p.parseKeyword(part, segment{text: part, start: start}.span(), anime)
+				start += len(part) + 1
+			}
 
 			continue
 		}
 
-		if lword == "special" || lword == "specials" || lword == "sps" {
-			anime.HasSpecials = true
+		p.parseKeyword(lword, word.span(), anime)
+	}
+}
+
+// parseKeyword updates `*anime` from a single lowercase word, found at span.
+func (p *Parser) parseKeyword(lword string, span Span, anime *Anime) {
+	if parseTag(segment{text: lword, start: span.Start}, anime) {
+		return
+	}
+
+	if lword == "bd" || lword == "bdrip" || lword == "blu-ray" || lword == "bluray" {
+		anime.IsBD = true
+		anime.Spans.IsBD = append(anime.Spans.IsBD, span)
 
-			continue
+		return
+	}
+
+	if lword == "special" || lword == "specials" || lword == "sps" {
+		anime.HasSpecials = true
+		anime.Spans.HasSpecials = append(anime.Spans.HasSpecials, span)
+
+		return
+	}
+
+	if marker := p.normalizeKeyword(lword); p.isRevisionMarker(marker) {
+		addRevisionMarker(anime, marker, span)
+
+		return
+	}
+
+	if m := regexpVersion.FindStringSubmatch(lword); m != nil {
+		if version, err := strconv.Atoi(m[1]); err == nil {
+			anime.Revision.Version = version
+			anime.Spans.Revision = append(anime.Spans.Revision, span)
 		}
+
+		return
 	}
+
+	if lword == "raw" || lword == "raws" {
+		anime.IsRaw = true
+		anime.Spans.IsRaw = append(anime.Spans.IsRaw, span)
+
+		return
+	}
+
+	if lword == "hardsub" || lword == "hardsubs" {
+		anime.Subs = SubsHard
+		anime.Spans.Subs = []Span{span}
+
+		return
+	}
+
+	if lword == "softsub" || lword == "softsubs" {
+		anime.Subs = SubsSoft
+		anime.Spans.Subs = []Span{span}
+
+		return
+	}
+
+	// Ignore if we already have it.
+	if anime.Resolution == "" {
+		if resolution := p.normalizeKeyword(lword); p.isResolution(resolution) {
+			anime.Resolution = resolution
+			anime.Spans.Resolution = []Span{span}
+
+			return
+		}
+
+		if m := regexpDimensions.FindStringSubmatch(lword); m != nil {
+			anime.Resolution = m[1] + "p"
+			anime.Spans.Resolution = []Span{span}
+
+			return
+		}
+	}
+
+	// Ignore if we already have it.
+	if keyword := p.normalizeKeyword(lword); p.keywords[keyword] == CategoryHDR {
+		if anime.HDR == HDRNone {
+			anime.HDR = p.hdrFormat(keyword)
+			anime.Spans.HDR = []Span{span}
+		}
+
+		return
+	}
+
+	// Ignore if we already have it.
+	if anime.FrameRate == "" {
+		if m := regexpFrameRate.FindStringSubmatch(lword); m != nil {
+			anime.FrameRate = m[1]
+			anime.Spans.FrameRate = []Span{span}
+
+			return
+		}
+
+		if lword == "vfr" || lword == "cfr" {
+			anime.FrameRate = strings.ToUpper(lword)
+			anime.Spans.FrameRate = []Span{span}
+
+			return
+		}
+
+		if p.isFrameRate(lword) {
+			anime.FrameRate = lword
+			anime.Spans.FrameRate = []Span{span}
+
+			return
+		}
+	}
+
+	// Ignore if we already have it.
+	if keyword := p.normalizeKeyword(lword); p.keywords[keyword] == CategoryPlatform && anime.Platform == "" {
+		anime.Platform = p.platformName(keyword)
+		anime.Spans.Platform = []Span{span}
+
+		return
+	}
+}
+
+// platformName returns the name stored in `Anime.Platform` for a platform
+// keyword.
+func (p *Parser) platformName(keyword string) string {
+	if name, ok := p.platformNames[keyword]; ok {
+		return name
+	}
+
+	return keyword
+}
+
+// hdrFormat returns the value stored in `Anime.HDR` for an HDR keyword.
+func (p *Parser) hdrFormat(keyword string) HDRFormat {
+	if format, ok := p.hdrFormats[keyword]; ok {
+		return format
+	}
+
+	return HDR10
 }
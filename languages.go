@@ -0,0 +1,110 @@
+package animenames
+
+import (
+	"strconv"
+	"strings"
+)
+
+// LanguagePack contains the words used by a language for common information
+// in names.
+//
+// Words are case-insensitive.
+type LanguagePack struct {
+	Name string
+
+	Season   []string // e.g. "Temporada" in "Temporada 2"
+	Episode  []string // e.g. "Capitulo" in "Capitulo 05"
+	Complete []string // e.g. "Completa"
+	Dub      []string // e.g. "Dublado"
+	Sub      []string // e.g. "Legendado"
+}
+
+var (
+	LanguagePackSpanish = LanguagePack{
+		Name:     "Spanish",
+		Season:   []string{"temporada"},
+		Episode:  []string{"capitulo", "capítulo", "episodio"},
+		Complete: []string{"completa", "completo"},
+		Dub:      []string{"doblado", "doblaje"},
+		Sub:      []string{"subtitulado", "subtitulos", "subtítulos"},
+	}
+	LanguagePackPortuguese = LanguagePack{
+		Name:     "Portuguese",
+		Season:   []string{"temporada"},
+		Episode:  []string{"episodio", "episódio"},
+		Complete: []string{"completa", "completo"},
+		Dub:      []string{"dublado"},
+		Sub:      []string{"legendado", "legendas"},
+	}
+	LanguagePackFrench = LanguagePack{
+		Name:     "French",
+		Season:   []string{"saison"},
+		Episode:  []string{"episode", "épisode"},
+		Complete: []string{"complet", "complète", "integrale", "intégrale"},
+		Dub:      []string{"vf"},
+		Sub:      []string{"vostfr", "vost"},
+	}
+	LanguagePackGerman = LanguagePack{
+		Name:     "German",
+		Season:   []string{"staffel"},
+		Episode:  []string{"folge", "episode"},
+		Complete: []string{"komplett"},
+		Dub:      []string{"gerdub"},
+		Sub:      []string{"gersub", "untertitel"},
+	}
+	LanguagePackItalian = LanguagePack{
+		Name:     "Italian",
+		Season:   []string{"stagione"},
+		Episode:  []string{"episodio"},
+		Complete: []string{"completa", "completo"},
+		Dub:      []string{"doppiato"},
+		Sub:      []string{"sottotitoli", "sottotitolato"},
+	}
+)
+
+// addAliases adds words as aliases of keyword.
+func (p *Parser) addAliases(words []string, keyword string) {
+	for _, word := range words {
+		p.aliases[strings.ToLower(word)] = keyword
+	}
+}
+
+// parseLabeledNumber updates `*anime` when label is a word that precedes
+// season or episode numbers (e.g. "Temporada" in "Temporada 2").
+//
+// The boolean values report whether an episode and a season were found.
+func (p *Parser) parseLabeledNumber(label, number segment, anime *Anime) (bool, bool) {
+	lword := strings.ToLower(label.text)
+
+	m := regexpEpisode.FindStringSubmatch(number.text)
+	if m == nil {
+		return false, false
+	}
+
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return false, false
+	}
+
+	// The span covers both words, like the span of "第二季".
+	span := Span{
+		Start: label.start,
+		End:   number.start + len(number.text),
+	}
+
+	if p.seasonWords[lword] {
+		anime.Season = n
+		anime.Spans.Season = []Span{span}
+
+		return false, true
+	}
+
+	if p.episodeWords[lword] {
+		anime.Episode = n
+		anime.Spans.Episode = []Span{span}
+
+		return true, false
+	}
+
+	return false, false
+}
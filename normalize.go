@@ -0,0 +1,80 @@
+package animenames
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// normalizeRune folds fullwidth forms of ASCII characters (e.g. "０１",
+// "［Group］" or "（２０１５）") and ideographic spaces into their ASCII
+// equivalents, and replaces Japanese quotation marks with spaces.
+//
+// Every rune is replaced by exactly one rune, so rune offsets are the same
+// before and after normalizing.
+func normalizeRune(r rune) rune {
+	switch {
+	case r >= '！' && r <= '～':
+		return r - '！' + '!'
+	case r == '　':
+		return ' '
+	case r == '「' || r == '」' || r == '『' || r == '』':
+		return ' '
+	}
+
+	return r
+}
+
+// normalize returns name with every rune folded by `normalizeRune`.
+func normalize(name string) string {
+	return strings.Map(normalizeRune, name)
+}
+
+// normalizedOffsets returns, for every byte offset in `normalize(name)`, the
+// byte offset in name of the rune it belongs to, followed by `len(name)`.
+func normalizedOffsets(name string) []int {
+	offsets := make([]int, 0, len(name)+1)
+
+	for i, r := range name {
+		for j := 0; j < utf8.RuneLen(normalizeRune(r)); j++ {
+			offsets = append(offsets, i)
+		}
+	}
+
+	return append(offsets, len(name))
+}
+
+// restoreOriginal returns the text from original that corresponds to s, where
+// s was found at span of the normalized form of original.
+//
+// Runes of s that are different from the normalized text (e.g. spaces that
+// were underscores) are kept. If s doesn't have as many runes as the text at
+// span, it's returned unchanged.
+func restoreOriginal(original, normalized, s string, span Span) string {
+	if s == "" || original == normalized {
+		return s
+	}
+
+	found := []rune(normalized[span.Start:span.End])
+
+	runes := []rune(s)
+	if len(runes) != len(found) {
+		return s
+	}
+
+	// Every rune is normalized into exactly one rune, so rune offsets are
+	// the same in both names.
+	start := utf8.RuneCountInString(normalized[:span.Start])
+
+	originalRunes := []rune(original)
+	if start+len(runes) > len(originalRunes) {
+		return s
+	}
+
+	for i, r := range runes {
+		if r == found[i] {
+			runes[i] = originalRunes[start+i]
+		}
+	}
+
+	return string(runes)
+}
@@ -0,0 +1,89 @@
+package animenames
+
+import (
+	"strings"
+)
+
+// Option configures a parser created by `NewParser`.
+type Option func(*Parser)
+
+// WithKeywords adds keywords to category.
+//
+// New platforms are stored in `Anime.Platform` as written in keywords (e.g.
+// "ABEMA"), and new HDR formats are stored in `Anime.HDR` as `HDR10`.
+func WithKeywords(category KeywordCategory, keywords ...string) Option {
+	return func(p *Parser) {
+		for _, keyword := range keywords {
+			lkeyword := strings.ToLower(keyword)
+
+			p.keywords[lkeyword] = category
+
+			if _, ok := p.platformNames[lkeyword]; category == CategoryPlatform && !ok {
+				p.platformNames[lkeyword] = keyword
+			}
+		}
+	}
+}
+
+// WithAliases adds aliases, mapping each alias to the keyword it stands for
+// (e.g. "bluray" to "bd").
+func WithAliases(aliases map[string]string) Option {
+	return func(p *Parser) {
+		for alias, keyword := range aliases {
+			p.aliases[strings.ToLower(alias)] = strings.ToLower(keyword)
+		}
+	}
+}
+
+// WithRegistry makes the parser use registry, instead of `DefaultRegistry`, to
+// recognize release groups.
+func WithRegistry(registry *Registry) Option {
+	return func(p *Parser) {
+		p.registry = registry
+	}
+}
+
+// WithLanguagePacks makes the parser recognize the words from packs, besides
+// the English ones.
+func WithLanguagePacks(packs ...LanguagePack) Option {
+	return func(p *Parser) {
+		for _, pack := range packs {
+			for _, word := range pack.Season {
+				p.seasonWords[strings.ToLower(word)] = true
+			}
+
+			for _, word := range pack.Episode {
+				p.episodeWords[strings.ToLower(word)] = true
+			}
+
+			p.addAliases(pack.Complete, "complete")
+			p.addAliases(pack.Dub, "dub")
+			p.addAliases(pack.Sub, "sub")
+		}
+	}
+}
+
+// WithDictionary adds the keywords and aliases from d, or replaces the
+// built-in ones if `d.Replace` is true.
+//
+// Dictionaries should be validated first, either by loading them with
+// `LoadDictionary` or by calling `Dictionary.Validate`.
+func WithDictionary(d *Dictionary) Option {
+	return func(p *Parser) {
+		d.apply(p)
+	}
+}
+
+// WithExtractor registers e with priority.
+//
+// Extractors with higher priority run first, and extractors with the same
+// priority run in the order they're registered. All of them run before the
+// built-in rules.
+func WithExtractor(priority int, e Extractor) Option {
+	return func(p *Parser) {
+		p.extractors = append(p.extractors, registeredExtractor{
+			extractor: e,
+			priority:  priority,
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package animenames
+
+import (
+	"strings"
+)
+
+// trackers contains lowercase tags added to names by trackers and websites
+// that re-upload releases (e.g. "[rartv]").
+var trackers = []string{
+	"animetosho",
+	"eztv",
+	"ettv",
+	"nyaa",
+	"rarbg",
+	"rartv",
+	"tgx",
+	"torrentgalaxy",
+	"yts",
+}
+
+var trackersMap = map[string]bool{}
+
+func init() {
+	for _, tracker := range trackers {
+		trackersMap[tracker] = true
+	}
+}
+
+// isOrigin returns true when s is a website domain or a known tracker tag, and
+// false otherwise.
+func isOrigin(s string) bool {
+	s = strings.TrimSpace(s)
+
+	return trackersMap[strings.ToLower(s)] || regexpDomain.MatchString(s)
+}
+
+// removeOrigins replaces website and tracker tags inside parens in name (e.g.
+// "[www.site.com]" or "[rartv]") with spaces, so offsets don't change, and
+// returns the result along with the first tag found.
+func removeOrigins(name string) (string, segment) {
+	chunks := splitParensSegments(segment{text: name})
+	if len(chunks) < 2 {
+		return name, segment{}
+	}
+
+	origin := segment{}
+	b := []byte(name)
+
+	for _, chunk := range chunks {
+		trimmed := trimSegment(chunk)
+
+		if noparens := stripSegment(trimmed); noparens.text != trimmed.text && isOrigin(noparens.text) {
+			if origin.text == "" {
+				origin = trimSegment(noparens)
+			}
+
+			copy(b[trimmed.start:], strings.Repeat(" ", len(trimmed.text)))
+		}
+	}
+
+	return string(b), origin
+}
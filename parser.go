@@ -4,6 +4,7 @@ import (
 	"container/list"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -18,21 +19,166 @@ var (
 	ErrInvalidYear       = errors.New("invalid year")
 )
 
-// Parse returns anime information from a file name.
+// Parser parses anime names.
+//
+// Each parser has its own keyword tables, so parsers with different rules can
+// be used at the same time. Parsers must not be modified after being created,
+// so they're safe for concurrent use.
+type Parser struct {
+	aliases  map[string]string
+	keywords map[string]KeywordCategory
+
+	// seasonWords and episodeWords contain lowercase words that precede
+	// season and episode numbers (e.g. "Temporada" in "Temporada 2").
+	seasonWords  map[string]bool
+	episodeWords map[string]bool
+
+	// platformNames and hdrFormats contain the values stored in `Anime` for
+	// some keywords.
+	platformNames map[string]string
+	hdrFormats    map[string]HDRFormat
+
+	subtitleLanguages map[string]string
+	airingSeasons     map[string]string
+
+	registry *Registry
+
+	// extractors are sorted by priority.
+	extractors []registeredExtractor
+
+	// trace is only set in copies made by `ParseWithTrace`.
+	trace *Trace
+}
+
+// NewParser returns a parser with the built-in keywords, modified by opts.
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{
+		aliases:  map[string]string{},
+		keywords: defaultKeywords(),
+
+		seasonWords:  map[string]bool{},
+		episodeWords: map[string]bool{},
+
+		platformNames: map[string]string{},
+		hdrFormats:    map[string]HDRFormat{},
+
+		subtitleLanguages: map[string]string{},
+		airingSeasons:     map[string]string{},
+
+		registry: DefaultRegistry,
+	}
+
+	for alias, keyword := range defaultAliases {
+		p.aliases[alias] = keyword
+	}
+
+	for keyword, name := range defaultPlatformNames {
+		p.platformNames[keyword] = name
+	}
+
+	for keyword, format := range defaultHDRFormats {
+		p.hdrFormats[keyword] = format
+	}
+
+	for tag, language := range defaultSubtitleLanguages {
+		p.subtitleLanguages[tag] = language
+	}
+
+	for name, season := range defaultAiringSeasons {
+		p.airingSeasons[name] = season
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	sort.SliceStable(p.extractors, func(i, j int) bool {
+		return p.extractors[i].priority > p.extractors[j].priority
+	})
+
+	return p
+}
+
+var defaultParser = NewParser()
+
+// Parse returns anime information from a file name, using the built-in
+// keywords.
 func Parse(name string) (Anime, error) {
+	return defaultParser.Parse(name)
+}
+
+// Parse returns anime information from a file name.
+func (p *Parser) Parse(name string) (Anime, error) {
 	anime := Anime{}
 
-	for _, ext := range extensions {
-		if strings.HasSuffix(name, "."+ext) {
-			name = strings.TrimSuffix(name, "."+ext)
+	// Extractors run first, and the built-in rules never see the tokens
+	// they claim.
+	if len(p.extractors) > 0 {
+		before := p.snapshot(&anime)
+		name = p.runExtractors(name, &anime)
+		p.decide(name, "extractors", before, &anime)
+	}
 
-			break
+	// Tokenize the normalized name, but return the title as written in the
+	// original name.
+	normalized := normalize(name)
+
+	// Website and tracker tags are removed first, so they're never mistaken
+	// for the group or the title.
+	stripped, origin := removeOrigins(normalized)
+
+	anime, err := p.parseNormalized(segment{text: stripped}, anime)
+	if origin.text != "" {
+		before := p.snapshot(&anime)
+		anime.Origin = origin.text
+		anime.Spans.Origin = spansOf(origin)
+		p.decide(origin.text, "website or tracker", before, &anime)
+	}
+	if len(anime.Spans.Title) > 0 {
+		anime.Title = restoreOriginal(name, normalized, anime.Title, anime.Spans.Title[0])
+	}
+
+	// Spans are found in the normalized name, and claimed tokens are
+	// replaced by spaces, so offsets in name are also offsets in the
+	// original name.
+	if normalized != name {
+		anime.Spans.mapOffsets(normalizedOffsets(name))
+	}
+
+	before := p.snapshot(&anime)
+	splitTitle(&anime)
+	if anime.TitleNative != "" || anime.TitleRomaji != "" {
+		p.decide(anime.Title, "title scripts", before, &anime)
+	}
+
+	return anime, err
+}
+
+// parseNormalized returns anime information from a normalized file name, added
+// to anime.
+func (p *Parser) parseNormalized(name segment, anime Anime) (Anime, error) {
+	// Origins removed from the beginning or the end of the name leave
+	// whitespace behind.
+	name = trimSegment(name)
+	name.text = p.trimExtension(name.text)
+
+	// Scene-style names use dots instead of spaces, and the group is at the
+	// end (e.g. "Spy.x.Family.S01E05.1080p.WEB.H264-GROUP").
+	sceneGroup := segment{}
+	scene := p.isDotSeparated(name.text)
+	if scene {
+		text, group := p.splitDotSeparated(name.text)
+		sceneGroup = segment{
+			text:  group,
+			start: name.start + len(name.text) - len(group),
 		}
+		name.text = text
+		p.decide(name.text, "scene name, with group "+strconv.Quote(sceneGroup.text), &anime, &anime)
 	}
 
-	chunks := textutil.SplitParens(name)
+	chunks := splitParensSegments(name)
 	if len(chunks) == 0 {
-		err := fmt.Errorf("%w: %#v", ErrCouldNotParseName, name)
+		err := fmt.Errorf("%w: %#v", ErrCouldNotParseName, name.text)
 
 		return anime, err
 	}
@@ -43,110 +189,181 @@ func Parse(name string) (Anime, error) {
 		var (
 			err error
 
-			chunk string
+			chunk segment
 		)
 
-		chunk = strings.TrimSpace(chunks[0])
+		chunk = trimSegment(chunks[0])
 
 		// Remove outer parens wrapping the whole chunk.
-		chunk = textutil.StripParens(chunk)
-		chunk = strings.TrimSpace(chunk)
+		noparens := stripSegment(chunk)
+		noparens = trimSegment(noparens)
 
-		chunk = strings.Join(removeKeywords(chunk), " ")
+		// Names with mixed outer + inner parens (e.g.
+		// "([Group] Title - 01 (720p))") are parsed again without the outer
+		// parens, so the information inside the inner parens isn't lost.
+		if noparens.text != chunk.text && len(textutil.SplitParens(noparens.text)) > 1 {
+			p.decide(chunk.text, "nested parens, parsing again without outer parens", &anime, &anime)
 
-		// Usually when there's parens wrapping everything, there's no inner
-		// parens.
-		//
-		// If names with mixed outer + inner parens start appearing, we
-		// probably should change this for a recursive call to `Parse`.
-		err = parseMain(chunk, &anime)
+			return p.parseNormalized(noparens, anime)
+		}
+
+		// Every word inside parens can be a keyword.
+		bracketed := noparens.text != chunk.text
+
+		chunk = noparens
+
+		before := p.snapshot(&anime)
+		chunk = removeTrailingChecksum(chunk, &anime)
+		if anime.Checksum != nil {
+			p.decide(chunk.text, "trailing checksum", before, &anime)
+		}
+
+		keywords, rest := p.splitKeywordsOutsideParens(splitWordSegments(chunk), scene || bracketed)
+
+		before = p.snapshot(&anime)
+		for _, keyword := range keywords {
+			p.parseKeywords(keyword, &anime)
+		}
+		p.decide(chunk.text, "keywords", before, &anime)
+
+		if scene {
+			before = p.snapshot(&anime)
+			if words, ok := removeSceneYear(rest, &anime); ok {
+				rest = words
+				p.decide(chunk.text, "scene year", before, &anime)
+			}
+		}
+
+		err = p.parseMain(rest, &anime)
 		if err != nil {
 			return anime, err
 		}
 
+		if anime.Group == "" {
+			before = p.snapshot(&anime)
+			anime.Group = sceneGroup.text
+			anime.Spans.Group = spansOf(sceneGroup)
+			p.decide(sceneGroup.text, "scene group", before, &anime)
+		}
+
+		p.parseGroups(&anime, nil, nil)
+
 		return anime, nil
 	}
 
-	return parseMultipleChunks(chunks)
-}
+	var err error
 
-func parseMultipleChunks(chunks []string) (Anime, error) {
-	anime := Anime{}
+	// Names where every field is inside parens (e.g.
+	// "[Group][Title][01][1080P][GB][MP4]") must be parsed by position.
+	if isFullyBracketed(chunks) {
+		p.decide(name.text, "every field inside parens, parsing by position", &anime, &anime)
+
+		anime, err = p.parseBracketedChunks(chunks, anime)
+	} else {
+		anime, err = p.parseMultipleChunks(chunks, anime)
+	}
+	if err != nil {
+		return anime, err
+	}
+
+	if anime.Group == "" && sceneGroup.text != "" {
+		before := p.snapshot(&anime)
+		anime.Group = sceneGroup.text
+		anime.Spans.Group = spansOf(sceneGroup)
+		p.decide(sceneGroup.text, "scene group", before, &anime)
+
+		p.parseGroups(&anime, nil, nil)
+	}
+
+	return anime, nil
+}
 
+func (p *Parser) parseMultipleChunks(chunks []segment, anime Anime) (Anime, error) {
 	l := chunksToList(chunks)
 
-	// Search CRC32, from right to left.
+	// Search checksum (usually CRC32), from right to left.
 	for e := l.Back(); e != nil; e = e.Prev() {
 		var (
 			err error
 
-			chunk string
+			chunk segment
 		)
 
-		chunk, err = elementToString(e)
+		chunk, err = elementToSegment(e)
 		if err != nil {
 			return anime, err
 		}
 
-		noparens := textutil.StripParens(chunk)
+		noparens := stripSegment(chunk)
 
-		// CRC32 must be inside parens.
-		if chunk == noparens {
+		// Checksum must be inside parens.
+		if chunk.text == noparens.text {
 			continue
 		}
 
 		chunk = noparens
 
-		// CRC32 is only one word, so we ignore chunks with more
+		// Checksum is only one word, so we ignore chunks with more
 		// than that.
-		words := splitByWords(chunk)
+		words := splitWordSegments(chunk)
 		if len(words) != 1 {
 			continue
 		}
 
-		checksum := words[0]
-		if !isCRC32(checksum) {
+		checksum, ok := parseChecksum(words[0].text)
+		if !ok {
 			continue
 		}
 
-		anime.CRC32 = checksum
+		before := p.snapshot(&anime)
+		setChecksum(&anime, checksum, words[0])
+		p.decide(chunk.text, "checksum inside parens", before, &anime)
 
 		l.Remove(e)
 
 		break
 	}
 
+	// Checksum outside parens, at the end of the name.
+	if anime.Checksum == nil {
+		if e := l.Back(); e != nil {
+			chunk, err := elementToSegment(e)
+			if err != nil {
+				return anime, err
+			}
+
+			if chunk.text == textutil.StripParens(chunk.text) {
+				before := p.snapshot(&anime)
+				e.Value = removeTrailingChecksum(chunk, &anime)
+				if anime.Checksum != nil {
+					p.decide(chunk.text, "trailing checksum", before, &anime)
+				}
+			}
+		}
+	}
+
 	// Remove extension, searching from right to left.
 	for e := l.Back(); e != nil; e = e.Prev() {
 		var (
 			err error
 
-			chunk string
+			chunk segment
 		)
 
-		chunk, err = elementToString(e)
+		chunk, err = elementToSegment(e)
 		if err != nil {
 			return anime, err
 		}
 
-		noparens := textutil.StripParens(chunk)
-
 		// Extension can't be inside parens.
-		if chunk != noparens {
+		if chunk.text != textutil.StripParens(chunk.text) {
 			continue
 		}
 
-		chunk = strings.TrimSpace(chunk)
+		chunk = trimSegment(chunk)
 
 		// Compare against a list of common extensions.
-		for _, ext := range extensions {
-			if strings.HasSuffix(chunk, "."+ext) {
-				// Remove the extension.
-				chunk = strings.TrimSuffix(chunk, "."+ext)
-
-				break
-			}
-		}
+		chunk.text = p.trimExtension(chunk.text)
 
 		// Remove the extension from the current element, but keep the element
 		// in the list because it might contain additional information.
@@ -155,24 +372,64 @@ func parseMultipleChunks(chunks []string) (Anime, error) {
 		break
 	}
 
+	// Groups that collaborated in the release, besides the ones found in
+	// `anime.Group`, and their spans.
+	extraGroups := make([]string, 0)
+	extraSpans := make([]Span, 0)
+
 	// Try to find the group/fansub at the leftmost chunk.
 	if e := l.Front(); e != nil {
 		var (
 			err error
 
-			chunk string
+			chunk segment
 		)
 
-		chunk, err = elementToString(e)
+		chunk, err = elementToSegment(e)
 		if err != nil {
 			return anime, err
 		}
 
 		// Group is usually inside parens.
-		if noparens := textutil.StripParens(chunk); chunk != noparens {
-			anime.Group = noparens
+		//
+		// Parens containing only keywords (e.g. "[1080p]") are not a group.
+		if noparens := stripSegment(chunk); chunk.text != noparens.text && len(p.removeKeywords(splitWordSegments(noparens))) > 0 {
+			before := p.snapshot(&anime)
+			anime.Group = noparens.text
+			anime.Spans.Group = spansOf(trimSegment(noparens))
+			p.decide(chunk.text, "group at the leftmost chunk", before, &anime)
 
 			l.Remove(e)
+
+			// Collaborations are sometimes written as consecutive parens
+			// (e.g. "[Group1][Group2]").
+			extraGroups, extraSpans, err = p.removeLeadingGroups(l)
+			if err != nil {
+				return anime, err
+			}
+
+			if len(extraGroups) > 0 {
+				p.decide(strings.Join(extraGroups, " "), "known groups after the leftmost chunk", &anime, &anime)
+			}
+		}
+	}
+
+	// Try to find the group after the last dash of the rightmost chunk outside
+	// parens (e.g. "Title - 01 [1080p]-GROUP").
+	//
+	// Groups inside parens at the right side are found later, when no other
+	// use is found for them.
+	if anime.Group == "" {
+		group, err := p.removeSuffixGroup(l)
+		if err != nil {
+			return anime, err
+		}
+
+		if group.text != "" {
+			before := p.snapshot(&anime)
+			anime.Group = group.text
+			anime.Spans.Group = spansOf(group)
+			p.decide(group.text, "group after the last dash", before, &anime)
 		}
 	}
 
@@ -181,7 +438,7 @@ func parseMultipleChunks(chunks []string) (Anime, error) {
 
 	// Used to keep track of text inside parens that's actually part of the
 	// title.
-	titleSuffix := ""
+	titleSuffix := segment{}
 	keepTitleSuffix := false
 
 	// When naming files, there's a tendency to put the series name at the left
@@ -195,40 +452,52 @@ func parseMultipleChunks(chunks []string) (Anime, error) {
 		if keepTitleSuffix {
 			keepTitleSuffix = false
 		} else {
-			titleSuffix = ""
+			titleSuffix = segment{}
 		}
 
 		var (
 			err error
 
-			chunk string
+			chunk segment
 		)
 
-		chunk, err = elementToString(e)
+		chunk, err = elementToSegment(e)
 		if err != nil {
-			return anime, fmt.Errorf("invalid chunk %#v: %w", chunk, err)
+			return anime, fmt.Errorf("invalid chunk %#v: %w", chunk.text, err)
 		}
 
-		chunk = strings.TrimSpace(chunk)
-		if chunk == "" {
+		chunk = trimSegment(chunk)
+		if chunk.text == "" {
 			continue
 		}
 
-		noparens := textutil.StripParens(chunk)
+		noparens := stripSegment(chunk)
 
-		if chunk != noparens {
+		before := p.snapshot(&anime)
+
+		if chunk.text != noparens.text {
 			// `chunk` was surrounded by parens. Chances are there's some
 			// keywords in here.
-			parseKeywords(noparens, &anime)
+			p.parseKeywords(noparens, &anime)
 		}
 
 		// Keywords should be parsed already. We don't need them.
 		//
 		// NOTE: Maybe combine with `parseKeywords`.
-		words := removeKeywords(noparens)
+		words := p.removeKeywords(splitWordSegments(noparens))
+		if chunk.text == noparens.text {
+			var keywords []segment
+
+			keywords, words = p.splitKeywordsOutsideParens(splitWordSegments(noparens), false)
+			for _, keyword := range keywords {
+				p.parseKeywords(keyword, &anime)
+			}
+		}
 
 		// No words left. Nothing to do.
 		if len(words) == 0 {
+			p.decide(chunk.text, "only keywords", before, &anime)
+
 			continue
 		}
 
@@ -236,45 +505,117 @@ func parseMultipleChunks(chunks []string) (Anime, error) {
 		// keyword, assume all words are keywords (known or unknown).
 		//
 		// TODO: Simplify.
-		if allwords := splitByWords(chunk); chunk != noparens && len(allwords) > len(words) {
+		if allwords := splitByWords(chunk.text); chunk.text != noparens.text && len(allwords) > len(words) {
+			p.decide(chunk.text, "assume all words are keywords", before, &anime)
+
 			continue
 		}
 
 		// Year.
 		//
 		// Ignore if we already have it.
-		if anime.Year == 0 && len(words) == 1 && regexpYear.MatchString(noparens) {
+		if anime.Year == 0 && len(words) == 1 && regexpYear.MatchString(noparens.text) {
 			var year int
 
-			year, err = strconv.Atoi(noparens)
+			year, err = strconv.Atoi(noparens.text)
 			if err != nil {
-				return anime, fmt.Errorf("could not parse %#v: %w", noparens, ErrInvalidYear)
+				return anime, fmt.Errorf("could not parse %#v: %w", noparens.text, ErrInvalidYear)
 			}
 
 			anime.Year = year
+			anime.Spans.Year = spansOf(noparens)
+			p.decide(chunk.text, "year", before, &anime)
+
+			continue
+		}
+
+		// Size, duration or date inside parens (e.g. "[1.2 GB]").
+		if chunk.text != noparens.text && parseTag(trimSegment(noparens), &anime) {
+			p.decide(chunk.text, "size, duration or date", before, &anime)
 
 			continue
 		}
 
+		// Episode count inside parens (e.g. "[12 eps]" or "(03/12)").
+		if chunk.text != noparens.text {
+			if rest, ok := removeEpisodeCount(splitWordSegments(noparens), &anime); ok && strings.TrimSpace(strings.Join(segmentTexts(rest), " ")) == "" {
+				p.decide(chunk.text, "episode count", before, &anime)
+
+				continue
+			}
+		}
+
+		// CJK markers inside parens (e.g. "【第01話】").
+		if chunk.text != noparens.text {
+			if rest, episode, season := parseCJKMarkers(noparens, &anime); (episode || season) && strings.TrimSpace(rest.text) == "" {
+				p.decide(chunk.text, "CJK markers", before, &anime)
+
+				continue
+			}
+		}
+
+		// Year range, e.g. "(2013-2015)".
+		//
+		// Ignore if we already have a year.
+		if anime.Year == 0 && parseYearRange(trimSegment(noparens), &anime) {
+			p.decide(chunk.text, "year range", before, &anime)
+
+			continue
+		}
+
+		// Season or episode number with a label from a language pack inside
+		// parens (e.g. "[Temporada 2]").
+		if chunk.text != noparens.text && len(words) == 2 {
+			if episode, season := p.parseLabeledNumber(words[0], words[1], &anime); episode || season {
+				p.decide(chunk.text, "labeled season or episode number", before, &anime)
+
+				continue
+			}
+		}
+
+		// Airing season, e.g. "[Winter 2023]".
+		//
+		// Ignore if we already have it.
+		if anime.AiringSeason == "" && len(words) == 2 {
+			if season, year, ok := p.parseAiringSeason(words[0].text, words[1].text); ok {
+				anime.AiringSeason = season
+				anime.Spans.AiringSeason = spansOf(words[0])
+				if anime.Year == 0 {
+					anime.Year = year
+					anime.Spans.Year = spansOf(words[1])
+				}
+				p.decide(chunk.text, "airing season", before, &anime)
+
+				continue
+			}
+		}
+
 		// Episode number.
 		//
 		// Ignore if we already have it.
 		//
 		// Some shows have an episode 0. In those cases it should be
 		// parsed to 0 again (must confirm this).
-		if anime.Episode == 0 && len(words) == 1 && regexpEpisode.MatchString(noparens) {
+		if m := regexpEpisode.FindStringSubmatch(noparens.text); anime.Episode == 0 && len(words) == 1 && m != nil {
 			var (
 				err error
 
 				episode int
 			)
 
-			episode, err = strconv.Atoi(noparens)
+			episode, err = strconv.Atoi(m[1])
 			if err != nil {
-				return anime, fmt.Errorf("could not parse %#v: %w", noparens, ErrInvalidEpisode)
+				return anime, fmt.Errorf("could not parse %#v: %w", noparens.text, ErrInvalidEpisode)
+			}
+
+			err = parseVersion(m[2], noparens, &anime)
+			if err != nil {
+				return anime, fmt.Errorf("could not parse %#v: %w", noparens.text, ErrInvalidEpisode)
 			}
 
 			anime.Episode = episode
+			anime.Spans.Episode = spansOf(noparens)
+			p.decide(chunk.text, "episode number", before, &anime)
 
 			continue
 		}
@@ -282,8 +623,13 @@ func parseMultipleChunks(chunks []string) (Anime, error) {
 		// Group.
 		//
 		// Ignore if we already have it.
-		if anime.Group == "" && len(words) == 1 {
-			anime.Group = words[0]
+		//
+		// A lone word outside parens is more likely a single-word title,
+		// unless it's a known group.
+		if anime.Group == "" && len(words) == 1 && (chunk.text != noparens.text || p.isKnownGroup(words[0].text)) {
+			anime.Group = words[0].text
+			anime.Spans.Group = spansOf(words[0])
+			p.decide(chunk.text, "single word as group", before, &anime)
 
 			continue
 		}
@@ -292,10 +638,14 @@ func parseMultipleChunks(chunks []string) (Anime, error) {
 		// it.
 		//
 		// Just ignore.
-		if chunk != noparens {
-			if len(chunk) >= 2 && chunk[0] == '(' && chunk[len(chunk)-1] == ')' {
+		if chunk.text != noparens.text {
+			if len(chunk.text) >= 2 && chunk.text[0] == '(' && chunk.text[len(chunk.text)-1] == ')' {
 				titleSuffix = chunk
 				keepTitleSuffix = true
+
+				p.decide(chunk.text, "unknown text inside parens, kept as part of the title", before, &anime)
+			} else {
+				p.decide(chunk.text, "unknown text inside parens, ignored", before, &anime)
 			}
 
 			continue
@@ -304,39 +654,307 @@ func parseMultipleChunks(chunks []string) (Anime, error) {
 		// `chunk` is text outside parens. Most likely containing anime title
 		// and episode number.
 
-		if titleSuffix != "" {
-			chunk += " " + titleSuffix
+		words = splitWordSegments(chunk)
+		if titleSuffix.text != "" {
+			words = append(words, splitWordSegments(titleSuffix)...)
 		}
 
-		err = parseMain(chunk, &anime)
+		err = p.parseMain(words, &anime)
 		if err != nil {
-			return anime, fmt.Errorf("could not parse chunk %#v: %w", chunk, err)
+			return anime, fmt.Errorf("could not parse chunk %#v: %w", chunk.text, err)
 		}
 	}
 
+	p.parseGroups(&anime, extraGroups, extraSpans)
+
 	return anime, nil
 }
 
-// elementToString returns a list element as a string.
-func elementToString(e *list.Element) (string, error) {
+// parseGroups sets the information derived from `anime.Group` and the
+// additional groups found in the name, where `extraSpans[i]` is the span of
+// `extraGroups[i]`.
+func (p *Parser) parseGroups(anime *Anime, extraGroups []string, extraSpans []Span) {
+	if anime.Group == "" {
+		return
+	}
+
+	before := p.snapshot(anime)
+
+	groups := p.splitGroup(anime.Group)
+	anime.Groups = append(groups, extraGroups...)
+
+	for i, span := range extraSpans {
+		// Groups split from the same parens share their span.
+		if i > 0 && extraSpans[i-1] == span {
+			continue
+		}
+
+		anime.Spans.Group = append(anime.Spans.Group, span)
+	}
+
+	for i, group := range anime.Groups {
+		if p.isRawGroup(group) {
+			anime.IsRaw = true
+
+			if i < len(groups) {
+				anime.Spans.IsRaw = anime.Spans.Group[:1]
+			} else {
+				anime.Spans.IsRaw = []Span{extraSpans[i-len(groups)]}
+			}
+
+			break
+		}
+	}
+
+	p.decide(anime.Group, "groups", before, anime)
+}
+
+// isFullyBracketed returns true when every non-blank chunk is inside parens,
+// and there's enough chunks to contain at least a group, a title and an
+// episode number.
+//
+// A single field may be outside parens, as long as it's not the first one
+// and it's not separated from the parens around it (e.g. the title in
+// "【Group】Title【01】").
+func isFullyBracketed(chunks []segment) bool {
+	count := 0
+	unbracketed := 0
+
+	for _, chunk := range chunks {
+		trimmed := strings.TrimSpace(chunk.text)
+		if trimmed == "" {
+			continue
+		}
+
+		count++
+
+		if trimmed != textutil.StripParens(trimmed) {
+			continue
+		}
+
+		unbracketed++
+		if unbracketed > 1 || count == 1 || trimmed != chunk.text || strings.Contains(chunk.text, " - ") {
+			return false
+		}
+	}
+
+	return count >= 3
+}
+
+// parseBracketedChunks parses names where every field is inside parens.
+//
+// The first field is the group, and the first field that's not a keyword, a
+// number or a language is the title.
+func (p *Parser) parseBracketedChunks(chunks []segment, anime Anime) (Anime, error) {
+	titleFound := false
+
+	for _, chunk := range chunks {
+		chunk = trimSegment(chunk)
+		if chunk.text == "" {
+			continue
+		}
+
+		field := trimSegment(stripSegment(chunk))
+		if field.text == "" {
+			continue
+		}
+
+		before := p.snapshot(&anime)
+
+		if anime.Group == "" {
+			anime.Group = field.text
+			anime.Spans.Group = spansOf(field)
+			p.decide(chunk.text, "first field as group", before, &anime)
+
+			continue
+		}
+
+		// Language.
+		//
+		// Ignore if we already have it.
+		if language, ok := p.subtitleLanguages[strings.ToLower(field.text)]; ok && anime.Language == "" {
+			anime.Language = language
+			anime.Spans.Language = spansOf(field)
+			p.decide(chunk.text, "subtitle language", before, &anime)
+
+			continue
+		}
+
+		// Keywords only.
+		if len(p.removeKeywords(splitWordSegments(field))) == 0 {
+			p.parseKeywords(field, &anime)
+			p.decide(chunk.text, "only keywords", before, &anime)
+
+			continue
+		}
+
+		// Checksum.
+		//
+		// Ignore if we already have it.
+		if anime.Checksum == nil {
+			if checksum, ok := parseChecksum(field.text); ok {
+				setChecksum(&anime, checksum, field)
+				p.decide(chunk.text, "checksum", before, &anime)
+
+				continue
+			}
+		}
+
+		// CJK markers (e.g. "[第01話]").
+		if rest, episode, season := parseCJKMarkers(field, &anime); (episode || season) && strings.TrimSpace(rest.text) == "" {
+			p.decide(chunk.text, "CJK markers", before, &anime)
+
+			continue
+		}
+
+		// Episode number.
+		//
+		// Ignore if we already have it.
+		if anime.Episode == 0 && anime.Batch == nil {
+			if m := regexpEpisode.FindStringSubmatch(field.text); m != nil {
+				episode, err := strconv.Atoi(m[1])
+				if err != nil {
+					return anime, fmt.Errorf("could not parse %#v: %w", field.text, ErrInvalidEpisode)
+				}
+
+				err = parseVersion(m[2], field, &anime)
+				if err != nil {
+					return anime, fmt.Errorf("could not parse %#v: %w", field.text, ErrInvalidEpisode)
+				}
+
+				anime.Episode = episode
+				anime.Spans.Episode = spansOf(field)
+				p.decide(chunk.text, "episode number", before, &anime)
+
+				continue
+			}
+		}
+
+		// Title, possibly with additional information (e.g. "Title S2").
+		if !titleFound {
+			err := p.parseMain(splitWordSegments(field), &anime)
+			if err != nil {
+				return anime, fmt.Errorf("could not parse chunk %#v: %w", chunk.text, err)
+			}
+
+			titleFound = true
+
+			continue
+		}
+
+		p.parseKeywords(field, &anime)
+		p.decide(chunk.text, "field after the title, parsed as keywords", before, &anime)
+	}
+
+	p.parseGroups(&anime, nil, nil)
+
+	return anime, nil
+}
+
+// removeLeadingGroups removes known groups inside parens from the beginning of
+// the list, and returns them along with their spans.
+func (p *Parser) removeLeadingGroups(l *list.List) ([]string, []Span, error) {
+	groups := make([]string, 0)
+	spans := make([]Span, 0)
+
+	for e := l.Front(); e != nil; {
+		chunk, err := elementToSegment(e)
+		if err != nil {
+			return groups, spans, err
+		}
+
+		// Ignore whitespace between parens.
+		if strings.TrimSpace(chunk.text) == "" {
+			e = e.Next()
+
+			continue
+		}
+
+		noparens := stripSegment(chunk)
+		if chunk.text == noparens.text || !p.isKnownGroup(noparens.text) {
+			break
+		}
+
+		for _, group := range p.splitGroup(noparens.text) {
+			groups = append(groups, group)
+			spans = append(spans, trimSegment(noparens).span())
+		}
+
+		next := e.Next()
+		l.Remove(e)
+		e = next
+	}
+
+	return groups, spans, nil
+}
+
+// removeSuffixGroup removes the group after the last dash of the rightmost
+// chunk outside parens, and returns it.
+func (p *Parser) removeSuffixGroup(l *list.List) (segment, error) {
+	for e := l.Back(); e != nil; e = e.Prev() {
+		chunk, err := elementToSegment(e)
+		if err != nil {
+			return segment{}, err
+		}
+
+		if chunk.text != textutil.StripParens(chunk.text) {
+			continue
+		}
+
+		chunk = trimSegment(chunk)
+		if chunk.text == "" {
+			continue
+		}
+
+		words := splitWordSegments(chunk)
+		last := words[len(words)-1]
+
+		prefix, group, ok := p.splitGroupSuffix(last.text)
+		if !ok {
+			return segment{}, nil
+		}
+
+		e.Value = segment{
+			text:  chunk.text[:last.start-chunk.start+len(prefix)],
+			start: chunk.start,
+		}
+
+		return segment{text: group, start: last.start + len(prefix) + 1}, nil
+	}
+
+	return segment{}, nil
+}
+
+// elementToSegment returns a list element as a segment.
+func elementToSegment(e *list.Element) (segment, error) {
 	var (
 		ok bool
 
-		value string
+		value segment
 	)
 
-	value, ok = e.Value.(string)
+	value, ok = e.Value.(segment)
 
 	if !ok {
-		return "", ErrNotAString
+		return segment{}, ErrNotAString
 	}
 
 	return value, nil
 }
 
-// parseMain parses a chunk of text outside parens, and updates `*anime`.
-func parseMain(chunk string, anime *Anime) error {
-	words := splitByWords(chunk)
+// parseMain parses the words of a chunk of text outside parens, and updates
+// `*anime`.
+func (p *Parser) parseMain(words []segment, anime *Anime) error {
+	chunk := strings.Join(segmentTexts(words), " ")
+
+	// Episode count, which would otherwise be taken as an episode number.
+	before := p.snapshot(anime)
+	if rest, ok := removeEpisodeCount(words, anime); ok {
+		p.decide(chunk, "episode count", before, anime)
+
+		words = rest
+		chunk = strings.Join(segmentTexts(words), " ")
+	}
 
 	// `split` is the index of either the season number or the episode number,
 	// whichever has lower value.
@@ -348,7 +966,7 @@ func parseMain(chunk string, anime *Anime) error {
 
 	// Look for the season number or episode number.
 	for i, word := range words {
-		m := regexpSeasonEpisode.FindStringSubmatch(word)
+		m := regexpSeasonEpisode.FindStringSubmatch(word.text)
 		if m == nil {
 			continue
 		}
@@ -363,8 +981,12 @@ func parseMain(chunk string, anime *Anime) error {
 			return fmt.Errorf("could not parse %#v: %w", m[2], ErrInvalidEpisode)
 		}
 
+		before := p.snapshot(anime)
 		anime.Season = season
 		anime.Episode = episode
+		anime.Spans.Season = spansOf(word)
+		anime.Spans.Episode = spansOf(word)
+		p.decide(word.text, "season and episode number, discarding the words after them", before, anime)
 
 		split = i
 
@@ -372,7 +994,8 @@ func parseMain(chunk string, anime *Anime) error {
 	}
 
 	if split > -1 {
-		chunk = strings.Join(words[:split], " ")
+		words = words[:split]
+		chunk = strings.Join(segmentTexts(words), " ")
 	}
 
 	ignore := struct {
@@ -385,13 +1008,13 @@ func parseMain(chunk string, anime *Anime) error {
 		Volume:  false,
 	}
 
-	// Series title.
+	// Series title, and the words it was made from.
 	title := ""
+	titleWords := make([]segment, 0)
 
 	iterationCompleted := true
 	episodeNumberIndex := -1
 
-	words = splitByWords(chunk)
 	for i := len(words) - 1; i >= 0; i-- {
 		word := words[i]
 
@@ -399,21 +1022,107 @@ func parseMain(chunk string, anime *Anime) error {
 		// was found in the previous iteration (e.g. episode number).
 		if !iterationCompleted {
 			// Reset because anime title can't contain special words.
+			if title != "" {
+				p.decide(title, "reset title because a special word was found", anime, anime)
+			}
+
 			title = ""
+			titleWords = titleWords[:0]
 		}
 
 		iterationCompleted = false
 
+		before := p.snapshot(anime)
+
+		// Year range, e.g. "2013-2015".
+		//
+		// Must be checked before batches, because they look the same.
+		if anime.Year == 0 && parseYearRange(word, anime) {
+			p.decide(word.text, "year range", before, anime)
+
+			continue
+		}
+
+		// Airing season, e.g. "Winter 2023".
+		//
+		// Must be checked before episode numbers, because the year would
+		// otherwise be taken as one.
+		if anime.AiringSeason == "" && i > 0 {
+			if season, year, ok := p.parseAiringSeason(words[i-1].text, word.text); ok {
+				anime.AiringSeason = season
+				anime.Spans.AiringSeason = spansOf(words[i-1])
+				if anime.Year == 0 {
+					anime.Year = year
+					anime.Spans.Year = spansOf(word)
+				}
+				p.decide(words[i-1].text+" "+word.text, "airing season", before, anime)
+
+				// Skip the season name.
+				i--
+
+				continue
+			}
+		}
+
+		// CJK markers (e.g. "第01話" or "第二季"), possibly attached to other
+		// words.
+		if rest, episode, season := parseCJKMarkers(word, anime); episode || season {
+			p.decide(word.text, "CJK markers", before, anime)
+			before = p.snapshot(anime)
+
+			if episode {
+				ignore.Episode = true
+			}
+
+			if season {
+				ignore.Season = true
+			}
+
+			if rest.text == "" {
+				continue
+			}
+
+			word = rest
+		}
+
+		// Season or episode number preceded by a word from a language pack
+		// (e.g. "Temporada 2" or "Capitulo 05").
+		if i > 0 {
+			if episode, season := p.parseLabeledNumber(words[i-1], word, anime); episode || season {
+				p.decide(words[i-1].text+" "+word.text, "labeled season or episode number", before, anime)
+
+				if episode {
+					ignore.Episode = true
+				}
+
+				if season {
+					ignore.Season = true
+				}
+
+				// Skip the label.
+				i--
+
+				continue
+			}
+		}
+
 		// Episode number.
 		if !ignore.Episode {
 			// Simple episode number.
-			if m := regexpEpisode.FindStringSubmatch(word); m != nil {
+			if m := regexpEpisode.FindStringSubmatch(word.text); m != nil {
 				episode, err := strconv.Atoi(m[1])
 				if err != nil {
 					return err
 				}
 
+				err = parseVersion(m[2], word, anime)
+				if err != nil {
+					return err
+				}
+
 				anime.Episode = episode
+				anime.Spans.Episode = spansOf(word)
+				p.decide(word.text, "episode number", before, anime)
 
 				ignore.Episode = true
 				episodeNumberIndex = i
@@ -421,7 +1130,7 @@ func parseMain(chunk string, anime *Anime) error {
 			}
 
 			// More than one episode.
-			if m := regexpBatch.FindStringSubmatch(word); m != nil {
+			if m := regexpBatch.FindStringSubmatch(word.text); m != nil {
 				start, err := strconv.Atoi(m[1])
 				if err != nil {
 					return err
@@ -436,6 +1145,8 @@ func parseMain(chunk string, anime *Anime) error {
 					Start: start,
 					End:   end,
 				}
+				anime.Spans.Batch = spansOf(word)
+				p.decide(word.text, "batch", before, anime)
 
 				ignore.Episode = true
 
@@ -445,13 +1156,16 @@ func parseMain(chunk string, anime *Anime) error {
 
 		// Season.
 		if !ignore.Season {
-			if m := regexpSeason.FindStringSubmatch(word); m != nil {
+			if m := regexpSeason.FindStringSubmatch(word.text); m != nil {
 				season, err := strconv.Atoi(m[1])
 				if err != nil {
 					return err
 				}
 
 				anime.Season = season
+				anime.Spans.Season = spansOf(word)
+				p.decide(word.text, "season", before, anime)
+
 				ignore.Season = true
 
 				continue
@@ -460,8 +1174,10 @@ func parseMain(chunk string, anime *Anime) error {
 
 		// Check if name contains more than one season (e.g. a batch).
 		if ignore.Season && anime.Season != 0 {
-			if m := regexpSeason.FindStringSubmatch(word); m != nil {
+			if m := regexpSeason.FindStringSubmatch(word.text); m != nil {
 				anime.Season = 0
+				anime.Spans.Season = nil
+				p.decide(word.text, "more than one season", before, anime)
 
 				continue
 			}
@@ -469,13 +1185,16 @@ func parseMain(chunk string, anime *Anime) error {
 
 		// Volume.
 		if !ignore.Volume {
-			if m := regexpVolume.FindStringSubmatch(word); m != nil {
+			if m := regexpVolume.FindStringSubmatch(word.text); m != nil {
 				volume, err := strconv.Atoi(m[1])
 				if err != nil {
 					return err
 				}
 
 				anime.Volume = volume
+				anime.Spans.Volume = spansOf(word)
+				p.decide(word.text, "volume", before, anime)
+
 				ignore.Volume = true
 
 				continue
@@ -483,22 +1202,47 @@ func parseMain(chunk string, anime *Anime) error {
 		}
 
 		// Case sensitive.
-		if word == "OVA" {
+		if word.text == "OVA" {
 			anime.IsOVA = true
+			anime.Spans.IsOVA = append(anime.Spans.IsOVA, word.span())
+			p.decide(word.text, "OVA", before, anime)
+
+			continue
+		}
+
+		// Case sensitive, and only after the episode number, to avoid
+		// confusing them with words in the title.
+		if lword := strings.ToLower(word.text); word.text == strings.ToUpper(word.text) && p.isRevisionMarker(p.normalizeKeyword(lword)) && isAfterEpisode(segmentTexts(words), i) {
+			addRevisionMarker(anime, p.normalizeKeyword(lword), word.span())
+			p.decide(word.text, "revision marker", before, anime)
 
 			continue
 		}
 
 		// Case sensitive.
-		if word == "BD" {
+		if word.text == "RAW" {
+			anime.IsRaw = true
+			anime.Spans.IsRaw = append(anime.Spans.IsRaw, word.span())
+			p.decide(word.text, "raw", before, anime)
+
+			continue
+		}
+
+		// Case sensitive.
+		if word.text == "BD" {
 			anime.IsBD = true
+			anime.Spans.IsBD = append(anime.Spans.IsBD, word.span())
+			p.decide(word.text, "BD", before, anime)
 
 			continue
 		}
 
 		// Assume "+" is a separator.
-		if word == "+" {
-			parseKeywords(title, anime)
+		if word.text == "+" {
+			for _, w := range titleWords {
+				p.parseKeywords(w, anime)
+			}
+			p.decide(title, "\"+\" separator, words after it are keywords", before, anime)
 
 			continue
 		}
@@ -506,7 +1250,8 @@ func parseMain(chunk string, anime *Anime) error {
 		// If nothing matches, just add the word to the title.
 		//
 		// Remember we're reading from right to left.
-		title = word + " " + title
+		title = word.text + " " + title
+		titleWords = append([]segment{word}, titleWords...)
 
 		iterationCompleted = true
 	}
@@ -514,20 +1259,42 @@ func parseMain(chunk string, anime *Anime) error {
 	// Treat numbers at the beginning of the title as part of the title,
 	// instead of episode number.
 	if episodeNumberIndex == 0 {
-		title = words[0] + " " + title
+		before := p.snapshot(anime)
+		title = words[0].text + " " + title
+		titleWords = append([]segment{words[0]}, titleWords...)
 		anime.Episode = 0
+		anime.Spans.Episode = nil
+		p.decide(words[0].text, "number at the beginning is part of the title", before, anime)
 	}
 
 	// Remove some useless characters from the title.
 	title = strings.TrimSpace(title)
 	title = regexpSeriesTrim.ReplaceAllString(title, "")
 
+	before = p.snapshot(anime)
+	previousTitle := anime.Title
 	anime.Title = title
+	anime.Spans.Title = nil
+	if title != "" {
+		anime.Spans.Title = []Span{titleSpan(titleWords, title)}
+	}
+	if title != "" || previousTitle != "" {
+		p.decide(chunk, "title", before, anime)
+	}
 
 	return nil
 }
 
-func chunksToList(chunks []string) *list.List {
+// titleSpan returns the span of title, made from the text of words separated
+// by spaces, without leading spaces and trailing characters.
+func titleSpan(words []segment, title string) Span {
+	text, starts := joinSegments(words)
+	start := len(text) - len(strings.TrimLeft(text, " "))
+
+	return joinedSpan(words, starts, start, start+len(title))
+}
+
+func chunksToList(chunks []segment) *list.List {
 	l := list.New()
 
 	for _, chunk := range chunks {
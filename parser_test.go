@@ -1,16 +1,19 @@
 package animenames_test
 
 import (
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/c032/go-animenames"
 )
 
 var parserTests = map[string]*animenames.Anime{
 	"[HorribleSubs] Himouto! Umaru-chan - 01 [720p].mkv": &animenames.Anime{
-		Title:   "Himouto! Umaru-chan",
-		Episode: 1,
-		Group:   "HorribleSubs",
+		Title:      "Himouto! Umaru-chan",
+		Episode:    1,
+		Group:      "HorribleSubs",
+		Resolution: "720p",
 	},
 	"Himouto! Umaru-chan【01】【GB】【720P】【MP4】": &animenames.Anime{
 		Title:   "Himouto! Umaru-chan",
@@ -30,6 +33,11 @@ var parserTests = map[string]*animenames.Anime{
 	"[UTW-Mazui-MK] Toaru Majutsu no Index Movie - Endymion no Kiseki [BD 1080p Hi10p Dual Audio-FLAC][9e89d1ac].mkv": &animenames.Anime{
 		Title: "Toaru Majutsu no Index Movie - Endymion no Kiseki",
 		Group: "UTW-Mazui-MK",
+		Groups: []string{
+			"UTW",
+			"Mazui",
+			"MK",
+		},
 		CRC32: "9e89d1ac",
 
 		IsBD: true,
@@ -43,6 +51,9 @@ var parserTests = map[string]*animenames.Anime{
 		Title:   "Shimoneta",
 		Episode: 1,
 		Group:   "project-gxs",
+		Groups: []string{
+			"project-gxs",
+		},
 	},
 	"(project-gxs)_Charlotte_-_01_(10bit_720p).mkv": &animenames.Anime{
 		Title:   "Charlotte",
@@ -116,6 +127,9 @@ var parserTests = map[string]*animenames.Anime{
 		Title:   "Dragon Ball Super",
 		Episode: 3,
 		Group:   "project-gxs",
+		Revision: animenames.Revision{
+			Version: 3,
+		},
 	},
 	"[DeadFish] Working!! - S1 & S2 [BD][720p][MP4][AAC]": &animenames.Anime{
 		Title: "Working!!",
@@ -126,6 +140,9 @@ var parserTests = map[string]*animenames.Anime{
 		Title:   "Joukamachi no Dandelion",
 		Episode: 3,
 		Group:   "Senketsu Subs",
+		Revision: animenames.Revision{
+			Version: 2,
+		},
 	},
 	"[Senketsu Rips] Nagato Yuki-chan no Shoushitsu - 16.ass (END)": &animenames.Anime{
 		Title:   "Nagato Yuki-chan no Shoushitsu",
@@ -259,6 +276,555 @@ var parserTests = map[string]*animenames.Anime{
 		Episode: 23,
 		CRC32:   "05BD70FE",
 	},
+	"[Cleo] Shingeki no Kyojin (2013-2015) [BD 1080p]": &animenames.Anime{
+		Title:   "Shingeki no Kyojin",
+		Group:   "Cleo",
+		Year:    2013,
+		YearEnd: 2015,
+		IsBD:    true,
+	},
+	"Shingeki no Kyojin 2013-2015": &animenames.Anime{
+		Title:   "Shingeki no Kyojin",
+		Year:    2013,
+		YearEnd: 2015,
+	},
+	"[Judas] Jujutsu Kaisen Winter 2021 [1080p]": &animenames.Anime{
+		Title:        "Jujutsu Kaisen",
+		Group:        "Judas",
+		Year:         2021,
+		AiringSeason: "Winter",
+	},
+	"Bocchi the Rock! (Fall 2022) - 01 [1080p]": &animenames.Anime{
+		Title:        "Bocchi the Rock!",
+		Episode:      1,
+		Year:         2022,
+		AiringSeason: "Fall",
+	},
+	"[SubsPlease] Sousou no Frieren - 05 [CR WEB-DL 1080p]": &animenames.Anime{
+		Title:    "Sousou no Frieren",
+		Group:    "SubsPlease",
+		Episode:  5,
+		Platform: "Crunchyroll",
+	},
+	"[Erai-raws] Spy x Family - 05 [1080p AMZN.WEB-DL AVC AAC][MultiSub]": &animenames.Anime{
+		Title:    "Spy x Family",
+		Group:    "Erai-raws",
+		Episode:  5,
+		Platform: "Amazon",
+		IsRaw:    true,
+	},
+	"[Tsundere-Raws] Kimi ni Todoke - 01 [NF] [1080p]": &animenames.Anime{
+		Title:    "Kimi ni Todoke",
+		Group:    "Tsundere-Raws",
+		Episode:  1,
+		Platform: "Netflix",
+		IsRaw:    true,
+	},
+	"[Commie & Doki] Kokoro Connect - 01 [720p]": &animenames.Anime{
+		Title:   "Kokoro Connect",
+		Group:   "Commie & Doki",
+		Episode: 1,
+		Groups: []string{
+			"Commie",
+			"Doki",
+		},
+	},
+	"[Commie][Doki] Kokoro Connect - 02 [720p]": &animenames.Anime{
+		Title:   "Kokoro Connect",
+		Group:   "Commie",
+		Episode: 2,
+		Groups: []string{
+			"Commie",
+			"Doki",
+		},
+	},
+	"Toradora (2008)": &animenames.Anime{
+		Title: "Toradora",
+		Year:  2008,
+	},
+	"[1080p] Bakemonogatari [Coalgirls]": &animenames.Anime{
+		Title: "Bakemonogatari",
+		Group: "Coalgirls",
+	},
+	"[Ohys-Raws] Kanojo mo Kanojo - 01 (BS11 1280x720 x264 AAC).mp4": &animenames.Anime{
+		Title:   "Kanojo mo Kanojo",
+		Group:   "Ohys-Raws",
+		Episode: 1,
+		IsRaw:   true,
+	},
+	"[Leopard] Kanojo mo Kanojo - 02 RAW (BS11 1280x720 x264 AAC).mp4": &animenames.Anime{
+		Title:   "Kanojo mo Kanojo",
+		Group:   "Leopard",
+		Episode: 2,
+		IsRaw:   true,
+	},
+	"[Anon] Shingeki no Kyojin - 01 [Hardsub][1080p]": &animenames.Anime{
+		Title:   "Shingeki no Kyojin",
+		Group:   "Anon",
+		Episode: 1,
+		Subs:    animenames.SubsHard,
+	},
+	"[Anon] Shingeki no Kyojin - 02 [1080p] [Softsubs]": &animenames.Anime{
+		Title:   "Shingeki no Kyojin",
+		Group:   "Anon",
+		Episode: 2,
+		Subs:    animenames.SubsSoft,
+	},
+	"Spy.x.Family.S01E05.1080p.WEB.H264-GROUP.mkv": &animenames.Anime{
+		Title:   "Spy x Family",
+		Group:   "GROUP",
+		Season:  1,
+		Episode: 5,
+	},
+	"Dr.Stone.S03E01.1080p.CR.WEB-DL.AAC2.0.H.264-VARYG": &animenames.Anime{
+		Title:    "Dr.Stone",
+		Group:    "VARYG",
+		Season:   3,
+		Episode:  1,
+		Platform: "Crunchyroll",
+	},
+	"Hibike.Euphonium.Vol.1.1080p.BluRay.x264-GROUP": &animenames.Anime{
+		Title:  "Hibike Euphonium",
+		Group:  "GROUP",
+		Volume: 1,
+		IsBD:   true,
+	},
+	"Sousou no Frieren - 01 [1080p]-GROUP": &animenames.Anime{
+		Title:   "Sousou no Frieren",
+		Group:   "GROUP",
+		Episode: 1,
+	},
+	"Sousou no Frieren 02 (Group)": &animenames.Anime{
+		Title:   "Sousou no Frieren",
+		Group:   "Group",
+		Episode: 2,
+	},
+	"Sousou.no.Frieren.S01E03-GROUP[1080p]": &animenames.Anime{
+		Title:       "Sousou no Frieren",
+		TitleRomaji: "Sousou no Frieren",
+		Group:       "GROUP",
+		Season:      1,
+		Episode:     3,
+		Resolution:  "1080p",
+	},
+	"Sousou.no.Frieren.S01E03-GROUP[rartv]": &animenames.Anime{
+		Title:   "Sousou no Frieren",
+		Group:   "GROUP",
+		Season:  1,
+		Episode: 3,
+		Origin:  "rartv",
+	},
+	"Title.S01E01-GROUP[rartv]": &animenames.Anime{
+		Title:   "Title",
+		Group:   "GROUP",
+		Season:  1,
+		Episode: 1,
+		Origin:  "rartv",
+	},
+	"Naruto.S01E01-GROUP": &animenames.Anime{
+		Title:   "Naruto",
+		Group:   "GROUP",
+		Season:  1,
+		Episode: 1,
+	},
+	"Dr.Stone - 01": &animenames.Anime{
+		Title:   "Dr.Stone",
+		Episode: 1,
+	},
+	"[Nekomoe kissaten][Sousou no Frieren][05][1080p][CHS].mp4": &animenames.Anime{
+		Title:      "Sousou no Frieren",
+		Group:      "Nekomoe kissaten",
+		Episode:    5,
+		Resolution: "1080p",
+		Language:   "zh-Hans",
+	},
+	"[Sakurato][Kusuriya no Hitorigoto S2][12][1920x1080][BIG5][MP4]": &animenames.Anime{
+		Title:      "Kusuriya no Hitorigoto",
+		Group:      "Sakurato",
+		Season:     2,
+		Episode:    12,
+		Resolution: "1080p",
+		Language:   "zh-Hant",
+	},
+	"【Nekomoe】Frieren【02】": &animenames.Anime{
+		Title:   "Frieren",
+		Group:   "Nekomoe",
+		Episode: 2,
+	},
+	"[Group] 進撃の巨人 第二季 第05話 [1080p]": &animenames.Anime{
+		Title:   "進撃の巨人",
+		Group:   "Group",
+		Season:  2,
+		Episode: 5,
+	},
+	"葬送のフリーレン 第1集【1080P】": &animenames.Anime{
+		Title:   "葬送のフリーレン",
+		Episode: 1,
+	},
+	"[Group] 葬送のフリーレン【第十二回】": &animenames.Anime{
+		Title:   "葬送のフリーレン",
+		Group:   "Group",
+		Episode: 12,
+	},
+	"[Group] 進撃の巨人第2期 全12話 [BD]": &animenames.Anime{
+		Title:         "進撃の巨人",
+		Group:         "Group",
+		Season:        2,
+		TotalEpisodes: 12,
+		IsBD:          true,
+		Batch: &animenames.Batch{
+			Start: 1,
+			End:   12,
+		},
+	},
+	"[Group][葬送のフリーレン][第01-28話][1080P]": &animenames.Anime{
+		Title: "葬送のフリーレン",
+		Group: "Group",
+		Batch: &animenames.Batch{
+			Start: 1,
+			End:   28,
+		},
+	},
+	"［SubsPlease］　葬送のフリーレン　－　０５　［1080p］": &animenames.Anime{
+		Title:   "葬送のフリーレン",
+		Group:   "SubsPlease",
+		Episode: 5,
+	},
+	"［Ｔｉｔｌｅ］ Title - 01": &animenames.Anime{
+		Title:       "Title",
+		TitleRomaji: "Title",
+		Group:       "Title",
+		Episode:     1,
+	},
+	"［Group］ Ｔｉｔｌｅ_Ｔｗｏ - 01": &animenames.Anime{
+		Title:       "Ｔｉｔｌｅ Ｔｗｏ",
+		TitleRomaji: "Ｔｉｔｌｅ Ｔｗｏ",
+		Group:       "Group",
+		Episode:     1,
+	},
+	"進撃の巨人（２０１３）": &animenames.Anime{
+		Title: "進撃の巨人",
+		Year:  2013,
+	},
+	"「進撃の巨人」第01話": &animenames.Anime{
+		Title:   "進撃の巨人",
+		Episode: 1,
+	},
+	"ＳＰＹ×ＦＡＭＩＬＹ ０３": &animenames.Anime{
+		Title:   "ＳＰＹ×ＦＡＭＩＬＹ",
+		Episode: 3,
+	},
+	"[Group] 進撃の巨人 / Shingeki no Kyojin - 01 [1080p]": &animenames.Anime{
+		Title:       "進撃の巨人 / Shingeki no Kyojin",
+		TitleNative: "進撃の巨人",
+		TitleRomaji: "Shingeki no Kyojin",
+		Group:       "Group",
+		Episode:     1,
+	},
+	"[Group] 葬送のフリーレン Sousou no Frieren - 02 [1080p]": &animenames.Anime{
+		Title:       "葬送のフリーレン Sousou no Frieren",
+		TitleNative: "葬送のフリーレン",
+		TitleRomaji: "Sousou no Frieren",
+		Group:       "Group",
+		Episode:     2,
+	},
+	"[Group] Shingeki no Kyojin | Атака титанов - 03": &animenames.Anime{
+		Title:       "Shingeki no Kyojin | Атака титанов",
+		TitleNative: "Атака титанов",
+		TitleRomaji: "Shingeki no Kyojin",
+		Group:       "Group",
+		Episode:     3,
+	},
+	"([Group] Sousou no Frieren - 01 (720p))": &animenames.Anime{
+		Title:      "Sousou no Frieren",
+		Group:      "Group",
+		Episode:    1,
+		Resolution: "720p",
+	},
+	"[Sousou no Frieren (2023) [BD]]": &animenames.Anime{
+		Title: "Sousou no Frieren",
+		Year:  2023,
+		IsBD:  true,
+	},
+	"[[Group] Sousou no Frieren (2023) [BD 1080p]]": &animenames.Anime{
+		Title: "Sousou no Frieren",
+		Group: "Group",
+		Year:  2023,
+		IsBD:  true,
+	},
+	"[www.site.com] [Group] Sousou no Frieren - 04 [1080p]": &animenames.Anime{
+		Title:   "Sousou no Frieren",
+		Group:   "Group",
+		Episode: 4,
+		Origin:  "www.site.com",
+	},
+	"[AnimeTosho] Sousou no Frieren - 05 [1080p]": &animenames.Anime{
+		Title:   "Sousou no Frieren",
+		Episode: 5,
+		Origin:  "AnimeTosho",
+	},
+	"[Group][Dr.Stone][06][1080p]": &animenames.Anime{
+		Title:   "Dr.Stone",
+		Group:   "Group",
+		Episode: 6,
+	},
+	"[Group] Sousou no Frieren - 07 [1080p][d41d8cd98f00b204e9800998ecf8427e].mkv": &animenames.Anime{
+		Title:   "Sousou no Frieren",
+		Group:   "Group",
+		Episode: 7,
+		Checksum: &animenames.Checksum{
+			Algorithm: animenames.ChecksumMD5,
+			Value:     "D41D8CD98F00B204E9800998ECF8427E",
+		},
+	},
+	"[Group] Sousou no Frieren - 08 (da39a3ee5e6b4b0d3255bfef95601890afd80709)_Track02.ass": &animenames.Anime{
+		Title:   "Sousou no Frieren",
+		Group:   "Group",
+		Episode: 8,
+		Checksum: &animenames.Checksum{
+			Algorithm: animenames.ChecksumSHA1,
+			Value:     "DA39A3EE5E6B4B0D3255BFEF95601890AFD80709",
+		},
+	},
+	"[Group] Sousou no Frieren - 09 [ed2k:31d6cfe0d16ae931b73c59d7e0c089c0]": &animenames.Anime{
+		Title:   "Sousou no Frieren",
+		Group:   "Group",
+		Episode: 9,
+		Checksum: &animenames.Checksum{
+			Algorithm: animenames.ChecksumED2K,
+			Value:     "31D6CFE0D16AE931B73C59D7E0C089C0",
+		},
+	},
+	"Sousou_no_Frieren_-_10_2a6c448f.mkv": &animenames.Anime{
+		Title:   "Sousou no Frieren",
+		Episode: 10,
+		CRC32:   "2a6c448f",
+		Checksum: &animenames.Checksum{
+			Algorithm: animenames.ChecksumCRC32,
+			Value:     "2A6C448F",
+		},
+	},
+	"[Group] Sousou no Frieren Episode 03 of 28 [1080p]": &animenames.Anime{
+		Title:         "Sousou no Frieren",
+		Group:         "Group",
+		Episode:       3,
+		TotalEpisodes: 28,
+	},
+	"[Group] Sousou no Frieren - 04/28 [1080p]": &animenames.Anime{
+		Title:         "Sousou no Frieren",
+		Group:         "Group",
+		Episode:       4,
+		TotalEpisodes: 28,
+	},
+	"[Group] Sousou no Frieren [28 eps] [BD 1080p]": &animenames.Anime{
+		Title:         "Sousou no Frieren",
+		Group:         "Group",
+		TotalEpisodes: 28,
+		IsBD:          true,
+	},
+	"[Group] Ranma 1/2 - 05": &animenames.Anime{
+		Title:   "Ranma 1/2",
+		Group:   "Group",
+		Episode: 5,
+	},
+	"[Group] Suzume no Tojimari [2160p HDR10 HEVC 23.976]": &animenames.Anime{
+		Title:      "Suzume no Tojimari",
+		Group:      "Group",
+		Resolution: "2160p",
+		HDR:        animenames.HDR10,
+		FrameRate:  "23.976",
+	},
+	"[Group] Suzume no Tojimari (4K DV 60fps)": &animenames.Anime{
+		Title:      "Suzume no Tojimari",
+		Group:      "Group",
+		Resolution: "2160p",
+		HDR:        animenames.HDRDolbyVision,
+		FrameRate:  "60",
+	},
+	"Suzume.no.Tojimari.2160p.UHD.BluRay.HDR10+.VFR.x265-GROUP": &animenames.Anime{
+		Title:      "Suzume no Tojimari",
+		Group:      "GROUP",
+		Resolution: "2160p",
+		HDR:        animenames.HDR10Plus,
+		FrameRate:  "VFR",
+		IsBD:       true,
+	},
+	"Kimi.no.Na.wa.2016.1080p.BluRay.x264-GROUP": &animenames.Anime{
+		Title:      "Kimi no Na wa",
+		Group:      "GROUP",
+		Year:       2016,
+		Resolution: "1080p",
+		IsBD:       true,
+	},
+	"Sousou no Frieren - 11 [1.5GB][23m40s][2023-10-05]": &animenames.Anime{
+		Title:       "Sousou no Frieren",
+		Episode:     11,
+		Size:        1610612736,
+		Duration:    23*time.Minute + 40*time.Second,
+		ReleaseDate: time.Date(2023, time.October, 5, 0, 0, 0, 0, time.UTC),
+	},
+	"Sousou no Frieren - 12 [350 MB]": &animenames.Anime{
+		Title:   "Sousou no Frieren",
+		Episode: 12,
+		Size:    350 << 20,
+	},
+	"Sousou no Frieren - 14 350MB 0:23:40": &animenames.Anime{
+		Title:       "Sousou no Frieren",
+		TitleRomaji: "Sousou no Frieren",
+		Episode:     14,
+		Size:        350 << 20,
+		Duration:    23*time.Minute + 40*time.Second,
+	},
+	"NieR Automata Ver1.1a - 2B": &animenames.Anime{
+		Title:       "NieR Automata Ver1.1a - 2B",
+		TitleRomaji: "NieR Automata Ver1.1a - 2B",
+	},
+	"Ultraman 3m - 01": &animenames.Anime{
+		Title:       "Ultraman 3m",
+		TitleRomaji: "Ultraman 3m",
+		Episode:     1,
+	},
+	"[Group] Sousou no Frieren - 13v2 [1080p] [Fixed] (Reupload)": &animenames.Anime{
+		Title:   "Sousou no Frieren",
+		Group:   "Group",
+		Episode: 13,
+		Revision: animenames.Revision{
+			Version: 2,
+			Markers: []string{
+				"REUPLOAD",
+				"FIXED",
+			},
+		},
+	},
+	"Sousou.no.Frieren.S01E14.REPACK.1080p.WEB.H264-GROUP": &animenames.Anime{
+		Title:   "Sousou no Frieren",
+		Group:   "GROUP",
+		Season:  1,
+		Episode: 14,
+		Revision: animenames.Revision{
+			Markers: []string{
+				"REPACK",
+			},
+		},
+	},
+	"[Group] Sousou no Frieren - 15 PROPER [1080p]": &animenames.Anime{
+		Title:   "Sousou no Frieren",
+		Group:   "Group",
+		Episode: 15,
+		Revision: animenames.Revision{
+			Markers: []string{
+				"PROPER",
+			},
+		},
+	},
+	"Web Ghosts - 01": &animenames.Anime{
+		Title:   "Web Ghosts",
+		Episode: 1,
+	},
+	"CR Zone - 01": &animenames.Anime{
+		Title:   "CR Zone",
+		Episode: 1,
+	},
+	"NF Story - 02": &animenames.Anime{
+		Title:   "NF Story",
+		Episode: 2,
+	},
+	"[Group] Title - 01 CR WEB-DL 1080p": &animenames.Anime{
+		Title:      "Title",
+		Group:      "Group",
+		Episode:    1,
+		Platform:   "Crunchyroll",
+		Resolution: "1080p",
+	},
+	"[Group] Title - 01 HDR10 2160p": &animenames.Anime{
+		Title:      "Title",
+		Group:      "Group",
+		Episode:    1,
+		HDR:        animenames.HDR10,
+		Resolution: "2160p",
+	},
+	"[Group] Title - 01 HardSub": &animenames.Anime{
+		Title:   "Title",
+		Group:   "Group",
+		Episode: 1,
+		Subs:    animenames.SubsHard,
+	},
+	"[DeadFish] Title - 04 - Special [720p]": &animenames.Anime{
+		Title:   "Title",
+		Group:   "DeadFish",
+		Episode: 4,
+	},
+	"【Nekomoe】Frieren【02】【GB】【1080P】": &animenames.Anime{
+		Title:       "Frieren",
+		TitleRomaji: "Frieren",
+		Group:       "Nekomoe",
+		Episode:     2,
+		Resolution:  "1080p",
+		Language:    "zh-Hans",
+	},
+	"DV Squad - 01": &animenames.Anime{
+		Title:       "DV Squad",
+		TitleRomaji: "DV Squad",
+		Episode:     1,
+	},
+	"Dovi Tale - 01": &animenames.Anime{
+		Title:       "Dovi Tale",
+		TitleRomaji: "Dovi Tale",
+		Episode:     1,
+	},
+	"Suzume no Tojimari DV 2160p": &animenames.Anime{
+		Title:       "Suzume no Tojimari",
+		TitleRomaji: "Suzume no Tojimari",
+		Resolution:  "2160p",
+		HDR:         animenames.HDRDolbyVision,
+	},
+	"Fixed Star - 01": &animenames.Anime{
+		Title:       "Fixed Star",
+		TitleRomaji: "Fixed Star",
+		Episode:     1,
+	},
+	"FIXED Star - 01": &animenames.Anime{
+		Title:       "FIXED Star",
+		TitleRomaji: "FIXED Star",
+		Episode:     1,
+	},
+	"Proper Noun - 03": &animenames.Anime{
+		Title:       "Proper Noun",
+		TitleRomaji: "Proper Noun",
+		Episode:     3,
+	},
+	"[Group] Repack Heroes - 02 [1080p]": &animenames.Anime{
+		Title:       "Repack Heroes",
+		TitleRomaji: "Repack Heroes",
+		Group:       "Group",
+		Episode:     2,
+		Resolution:  "1080p",
+	},
+	"Sousou no Frieren - 16 Repack": &animenames.Anime{
+		Title:       "Sousou no Frieren",
+		TitleRomaji: "Sousou no Frieren",
+		Episode:     16,
+		Revision: animenames.Revision{
+			Markers: []string{
+				"REPACK",
+			},
+		},
+	},
+	"Sub Zero - 01": &animenames.Anime{
+		Title:       "Sub Zero",
+		TitleRomaji: "Sub Zero",
+		Episode:     1,
+	},
+	"Raw Hero - 01": &animenames.Anime{
+		Title:   "Raw Hero",
+		Episode: 1,
+	},
+	"[Group] Title - 01 [RAW]": &animenames.Anime{
+		Title:   "Title",
+		Group:   "Group",
+		Episode: 1,
+		IsRaw:   true,
+	},
 }
 
 func TestParse(t *testing.T) {
@@ -272,14 +838,30 @@ func TestParse(t *testing.T) {
 			t.Errorf("animenames.Parse(%#v).Title = %#v; expected %#v", name, gotAnime.Title, expectedAnime.Title)
 		}
 
+		if expectedAnime.TitleNative != "" && gotAnime.TitleNative != expectedAnime.TitleNative {
+			t.Errorf("animenames.Parse(%#v).TitleNative = %#v; expected %#v", name, gotAnime.TitleNative, expectedAnime.TitleNative)
+		}
+
+		if expectedAnime.TitleRomaji != "" && gotAnime.TitleRomaji != expectedAnime.TitleRomaji {
+			t.Errorf("animenames.Parse(%#v).TitleRomaji = %#v; expected %#v", name, gotAnime.TitleRomaji, expectedAnime.TitleRomaji)
+		}
+
 		if gotAnime.Year != expectedAnime.Year {
 			t.Errorf("animenames.Parse(%#v).Year = %#v; expected %#v", name, gotAnime.Year, expectedAnime.Year)
 		}
 
+		if gotAnime.YearEnd != expectedAnime.YearEnd {
+			t.Errorf("animenames.Parse(%#v).YearEnd = %#v; expected %#v", name, gotAnime.YearEnd, expectedAnime.YearEnd)
+		}
+
 		if gotAnime.Episode != expectedAnime.Episode {
 			t.Errorf("animenames.Parse(%#v).Episode = %#v; expected %#v", name, gotAnime.Episode, expectedAnime.Episode)
 		}
 
+		if gotAnime.TotalEpisodes != expectedAnime.TotalEpisodes {
+			t.Errorf("animenames.Parse(%#v).TotalEpisodes = %#v; expected %#v", name, gotAnime.TotalEpisodes, expectedAnime.TotalEpisodes)
+		}
+
 		if gotAnime.Season != expectedAnime.Season {
 			t.Errorf("animenames.Parse(%#v).Season = %#v; expected %#v", name, gotAnime.Season, expectedAnime.Season)
 		}
@@ -292,10 +874,64 @@ func TestParse(t *testing.T) {
 			t.Errorf("animenames.Parse(%#v).Group = %#v; expected %#v", name, gotAnime.Group, expectedAnime.Group)
 		}
 
+		if expectedAnime.Groups != nil && !reflect.DeepEqual(gotAnime.Groups, expectedAnime.Groups) {
+			t.Errorf("animenames.Parse(%#v).Groups = %#v; expected %#v", name, gotAnime.Groups, expectedAnime.Groups)
+		}
+
 		if gotAnime.CRC32 != expectedAnime.CRC32 {
 			t.Errorf("animenames.Parse(%#v).CRC32 = %#v; expected %#v", name, gotAnime.CRC32, expectedAnime.CRC32)
 		}
 
+		if expectedAnime.Checksum != nil && (gotAnime.Checksum == nil || *gotAnime.Checksum != *expectedAnime.Checksum) {
+			t.Errorf("animenames.Parse(%#v).Checksum = %#v; expected %#v", name, gotAnime.Checksum, expectedAnime.Checksum)
+		}
+
+		if gotAnime.AiringSeason != expectedAnime.AiringSeason {
+			t.Errorf("animenames.Parse(%#v).AiringSeason = %#v; expected %#v", name, gotAnime.AiringSeason, expectedAnime.AiringSeason)
+		}
+
+		if gotAnime.Platform != expectedAnime.Platform {
+			t.Errorf("animenames.Parse(%#v).Platform = %#v; expected %#v", name, gotAnime.Platform, expectedAnime.Platform)
+		}
+
+		if expectedAnime.Resolution != "" && gotAnime.Resolution != expectedAnime.Resolution {
+			t.Errorf("animenames.Parse(%#v).Resolution = %#v; expected %#v", name, gotAnime.Resolution, expectedAnime.Resolution)
+		}
+
+		if gotAnime.Language != expectedAnime.Language {
+			t.Errorf("animenames.Parse(%#v).Language = %#v; expected %#v", name, gotAnime.Language, expectedAnime.Language)
+		}
+
+		if gotAnime.Origin != expectedAnime.Origin {
+			t.Errorf("animenames.Parse(%#v).Origin = %#v; expected %#v", name, gotAnime.Origin, expectedAnime.Origin)
+		}
+
+		if gotAnime.HDR != expectedAnime.HDR {
+			t.Errorf("animenames.Parse(%#v).HDR = %#v; expected %#v", name, gotAnime.HDR, expectedAnime.HDR)
+		}
+
+		if gotAnime.FrameRate != expectedAnime.FrameRate {
+			t.Errorf("animenames.Parse(%#v).FrameRate = %#v; expected %#v", name, gotAnime.FrameRate, expectedAnime.FrameRate)
+		}
+
+		if gotAnime.Size != expectedAnime.Size {
+			t.Errorf("animenames.Parse(%#v).Size = %#v; expected %#v", name, gotAnime.Size, expectedAnime.Size)
+		}
+
+		if gotAnime.Duration != expectedAnime.Duration {
+			t.Errorf("animenames.Parse(%#v).Duration = %#v; expected %#v", name, gotAnime.Duration, expectedAnime.Duration)
+		}
+
+		if !gotAnime.ReleaseDate.Equal(expectedAnime.ReleaseDate) {
+			t.Errorf("animenames.Parse(%#v).ReleaseDate = %#v; expected %#v", name, gotAnime.ReleaseDate, expectedAnime.ReleaseDate)
+		}
+
+		if expectedAnime.Revision.Version != 0 || expectedAnime.Revision.Markers != nil {
+			if !reflect.DeepEqual(gotAnime.Revision, expectedAnime.Revision) {
+				t.Errorf("animenames.Parse(%#v).Revision = %#v; expected %#v", name, gotAnime.Revision, expectedAnime.Revision)
+			}
+		}
+
 		if gotAnime.IsOVA != expectedAnime.IsOVA {
 			t.Errorf("animenames.Parse(%#v).IsOVA = %#v; expected %#v", name, gotAnime.IsOVA, expectedAnime.IsOVA)
 		}
@@ -304,10 +940,22 @@ func TestParse(t *testing.T) {
 			t.Errorf("animenames.Parse(%#v).IsBD = %#v; expected %#v", name, gotAnime.IsBD, expectedAnime.IsBD)
 		}
 
+		if gotAnime.IsRaw != expectedAnime.IsRaw {
+			t.Errorf("animenames.Parse(%#v).IsRaw = %#v; expected %#v", name, gotAnime.IsRaw, expectedAnime.IsRaw)
+		}
+
+		if gotAnime.Subs != expectedAnime.Subs {
+			t.Errorf("animenames.Parse(%#v).Subs = %#v; expected %#v", name, gotAnime.Subs, expectedAnime.Subs)
+		}
+
 		if gotAnime.HasSpecials != expectedAnime.HasSpecials {
 			t.Errorf("animenames.Parse(%#v).HasSpecials = %#v; expected %#v", name, gotAnime.HasSpecials, expectedAnime.HasSpecials)
 		}
 
+		if expectedAnime.Batch == nil && gotAnime.Batch != nil {
+			t.Errorf("animenames.Parse(%#v).Batch = %#v; expected nil", name, gotAnime.Batch)
+		}
+
 		if expectedAnime.Batch != nil {
 			if gotAnime.Batch.Start != expectedAnime.Batch.Start {
 				t.Errorf("expecting Anime.Batch.Start of %#v to be %#v (got %#v)", name, expectedAnime.Batch.Start, gotAnime.Batch.Start)
@@ -319,3 +967,175 @@ func TestParse(t *testing.T) {
 		}
 	}
 }
+
+var titleScriptTests = map[string]*animenames.Anime{
+	"[Group] 進撃の巨人 Shingeki no Kyojin - 01": &animenames.Anime{
+		TitleNative: "進撃の巨人",
+		TitleRomaji: "Shingeki no Kyojin",
+	},
+	"[Group] Sousou no Frieren / 葬送のフリーレン - 01": &animenames.Anime{
+		TitleNative: "葬送のフリーレン",
+		TitleRomaji: "Sousou no Frieren",
+	},
+	"[Group] Shingeki no Kyojin - 01": &animenames.Anime{
+		TitleRomaji: "Shingeki no Kyojin",
+	},
+	"[Group] 進撃の巨人 - 01": &animenames.Anime{
+		TitleNative: "進撃の巨人",
+	},
+	"[Group] Атака титанов - 01": &animenames.Anime{
+		TitleNative: "Атака титанов",
+	},
+	"[Group] 86 - 01": &animenames.Anime{},
+}
+
+// TestParseTitleScripts compares both parts of the title, including the empty
+// ones, which `TestParse` skips.
+func TestParseTitleScripts(t *testing.T) {
+	for name, expectedAnime := range titleScriptTests {
+		gotAnime, err := animenames.Parse(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if gotAnime.TitleNative != expectedAnime.TitleNative {
+			t.Errorf("animenames.Parse(%#v).TitleNative = %#v; expected %#v", name, gotAnime.TitleNative, expectedAnime.TitleNative)
+		}
+
+		if gotAnime.TitleRomaji != expectedAnime.TitleRomaji {
+			t.Errorf("animenames.Parse(%#v).TitleRomaji = %#v; expected %#v", name, gotAnime.TitleRomaji, expectedAnime.TitleRomaji)
+		}
+	}
+}
+
+var languagePackTests = map[string]*animenames.Anime{
+	"[Group] Shingeki no Kyojin Temporada 2 Capitulo 05 [1080p]": &animenames.Anime{
+		Title:   "Shingeki no Kyojin",
+		Group:   "Group",
+		Season:  2,
+		Episode: 5,
+	},
+	"[Group] Shingeki no Kyojin (Saison 3) - 07 [VOSTFR]": &animenames.Anime{
+		Title:   "Shingeki no Kyojin",
+		Group:   "Group",
+		Season:  3,
+		Episode: 7,
+	},
+	"[Group] Shingeki no Kyojin Staffel 2 Folge 12 [GerSub]": &animenames.Anime{
+		Title:   "Shingeki no Kyojin",
+		Group:   "Group",
+		Season:  2,
+		Episode: 12,
+	},
+}
+
+func TestParseLanguagePacks(t *testing.T) {
+	parser := animenames.NewParser(
+		animenames.WithLanguagePacks(
+			animenames.LanguagePackSpanish,
+			animenames.LanguagePackPortuguese,
+			animenames.LanguagePackFrench,
+			animenames.LanguagePackGerman,
+			animenames.LanguagePackItalian,
+		),
+	)
+
+	for name, expectedAnime := range languagePackTests {
+		gotAnime, err := parser.Parse(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if gotAnime.Title != expectedAnime.Title {
+			t.Errorf("animenames.Parse(%#v).Title = %#v; expected %#v", name, gotAnime.Title, expectedAnime.Title)
+		}
+
+		if gotAnime.Group != expectedAnime.Group {
+			t.Errorf("animenames.Parse(%#v).Group = %#v; expected %#v", name, gotAnime.Group, expectedAnime.Group)
+		}
+
+		if gotAnime.Season != expectedAnime.Season {
+			t.Errorf("animenames.Parse(%#v).Season = %#v; expected %#v", name, gotAnime.Season, expectedAnime.Season)
+		}
+
+		if gotAnime.Episode != expectedAnime.Episode {
+			t.Errorf("animenames.Parse(%#v).Episode = %#v; expected %#v", name, gotAnime.Episode, expectedAnime.Episode)
+		}
+	}
+}
+
+func TestParseDashAfterEpisode(t *testing.T) {
+	// The word after the dash is not a group when the dash follows an
+	// episode number.
+	for name, expectedGroup := range map[string]string{
+		"Title - 01-END [1080p]":         "",
+		"[Group] Title - 01-END [1080p]": "Group",
+	} {
+		gotAnime, err := animenames.Parse(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if gotAnime.Group != expectedGroup {
+			t.Errorf("animenames.Parse(%#v).Group = %#v; expected %#v", name, gotAnime.Group, expectedGroup)
+		}
+	}
+}
+
+func TestParserWithKeywords(t *testing.T) {
+	const name = "Title - 01 [AV1]"
+
+	gotAnime, err := animenames.Parse(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotAnime.Group != "AV1" {
+		t.Errorf("animenames.Parse(%#v).Group = %#v; expected %#v", name, gotAnime.Group, "AV1")
+	}
+
+	parser := animenames.NewParser(
+		animenames.WithKeywords(animenames.CategoryVideoCodec, "AV1"),
+	)
+
+	gotAnime, err = parser.Parse(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotAnime.Group != "" {
+		t.Errorf("parser.Parse(%#v).Group = %#v; expected %#v", name, gotAnime.Group, "")
+	}
+}
+
+func TestParserWithPlatformAndHDRKeywords(t *testing.T) {
+	parser := animenames.NewParser(
+		animenames.WithKeywords(animenames.CategoryPlatform, "ABEMA"),
+		animenames.WithKeywords(animenames.CategoryHDR, "PQ"),
+	)
+
+	const name = "[Group] Title - 01 [ABEMA 2160p PQ]"
+
+	gotAnime, err := parser.Parse(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotAnime.Platform != "ABEMA" {
+		t.Errorf("parser.Parse(%#v).Platform = %#v; expected %#v", name, gotAnime.Platform, "ABEMA")
+	}
+
+	if gotAnime.HDR != animenames.HDR10 {
+		t.Errorf("parser.Parse(%#v).HDR = %#v; expected %#v", name, gotAnime.HDR, animenames.HDR10)
+	}
+
+	// Other parsers don't know the new keywords.
+	gotAnime, err = animenames.Parse(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotAnime.Platform != "" {
+		t.Errorf("animenames.Parse(%#v).Platform = %#v; expected %#v", name, gotAnime.Platform, "")
+	}
+}
@@ -2,22 +2,48 @@ package animenames
 
 import (
 	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
 )
 
 var (
 	regexpVolume        = regexp.MustCompile(`^[Vv]ol\.?([0-9]{1,2})$`)
 	regexpSeason        = regexp.MustCompile(`^S([0-9]+)$`)
 	regexpEpisode       = regexp.MustCompile(`^([0-9]+)(v[0-9]+)?$`)
+	regexpVersion       = regexp.MustCompile(`^v([0-9]+)$`)
 	regexpSeasonEpisode = regexp.MustCompile(`^S([0-9]+)E([0-9]+)$`)
 	regexpWordSplit     = regexp.MustCompile(`[\s_]`)
 	regexpYear          = regexp.MustCompile(`^[0-9]{4}$`)
+	regexpSceneYear     = regexp.MustCompile(`^(?:19|20)[0-9]{2}$`)
+	regexpYearRange     = regexp.MustCompile(`^((?:19|20)[0-9]{2})\s*[\-~]\s*((?:19|20)[0-9]{2})$`)
 	regexpBatch         = regexp.MustCompile(`([0-9]+)\-([0-9]+)`)
+	regexpEpisodeOf     = regexp.MustCompile(`(?i)(?:^|\s)(?:ep(?:isode)?\.?\s*)?([0-9]+)\s+of\s+([0-9]+)(?:\s|$)`)
+	regexpEpisodeSlash  = regexp.MustCompile(`(?:^|\s)([0-9]{2,})/([0-9]{2,})(?:\s|$)`)
+	regexpEpisodeCount  = regexp.MustCompile(`(?i)(?:^|\s)([0-9]+)\s*(?:eps|episodes)(?:\s|$)`)
+	regexpAudioChannels = regexp.MustCompile(`[0-9]\.[0-9]$`)
+	regexpFrameRate     = regexp.MustCompile(`^([0-9]{2,3}(?:\.[0-9]{1,3})?)fps$`)
+	regexpSize          = regexp.MustCompile(`(?i)^([0-9]+(?:\.[0-9]+)?)\s*(b|kb|kib|mb|mib|gb|gib|tb|tib)$`)
+	regexpDuration      = regexp.MustCompile(`^(?:([0-9]+)h)?(?:([0-9]+)m(?:in)?)?(?:([0-9]+)s)?$`)
+	regexpClock         = regexp.MustCompile(`^([0-9]+):([0-5][0-9]):([0-5][0-9])$`)
+	regexpDate          = regexp.MustCompile(`^((?:19|20)[0-9]{2})[\-\.]([0-9]{2})[\-\.]([0-9]{2})$`)
+	regexpDimensions    = regexp.MustCompile(`^[0-9]{3,4}x([0-9]{3,4})$`)
+
+	regexpCJKEpisode = regexp.MustCompile(`第([0-9]+|[〇零一二三四五六七八九十]+)[話话集回]`)
+	regexpCJKBatch   = regexp.MustCompile(`第([0-9]+|[〇零一二三四五六七八九十]+)[\-~～]([0-9]+|[〇零一二三四五六七八九十]+)[話话集回]`)
+	regexpCJKTotal   = regexp.MustCompile(`全([0-9]+|[〇零一二三四五六七八九十]+)[話话集回]`)
+	regexpCJKSeason  = regexp.MustCompile(`第([0-9]+|[〇零一二三四五六七八九十]+)[季期]`)
 
 	regexpSeriesTrim = regexp.MustCompile(`[\s\&\-]+$`)
+	regexpDomain     = regexp.MustCompile(`(?i)^(?:https?://)?(?:www\.)?(?:[a-z0-9\-]+\.)+(?:cc|co|com|in|info|io|la|me|moe|net|nu|org|ru|se|to|tv|ws|xyz)/?$`)
+	regexpTitleSplit = regexp.MustCompile(`\s+[/|]\s+`)
+	regexpGroupSplit = regexp.MustCompile(`\s*[\&\+,]\s*`)
+	regexpRawGroup   = regexp.MustCompile(`(?i)[\s\-_]raws?$`)
 )
 
-func isCRC32(s string) bool {
-	if len(s) != 8 {
+// isHex returns true when s is a non-empty string of hex digits.
+func isHex(s string) bool {
+	if s == "" {
 		return false
 	}
 
@@ -38,6 +64,97 @@ func isCRC32(s string) bool {
 	return true
 }
 
+// parseYearRange updates `*anime` with the first and last year from a word
+// like "2013-2015".
+//
+// The boolean value reports whether word is a year range.
+func parseYearRange(word segment, anime *Anime) bool {
+	m := regexpYearRange.FindStringSubmatchIndex(word.text)
+	if m == nil {
+		return false
+	}
+
+	start, err := strconv.Atoi(word.text[m[2]:m[3]])
+	if err != nil {
+		return false
+	}
+
+	end, err := strconv.Atoi(word.text[m[4]:m[5]])
+	if err != nil {
+		return false
+	}
+
+	if end < start {
+		return false
+	}
+
+	anime.Year = start
+	anime.YearEnd = end
+	anime.Spans.Year = []Span{{Start: word.start + m[2], End: word.start + m[3]}}
+	anime.Spans.YearEnd = []Span{{Start: word.start + m[4], End: word.start + m[5]}}
+
+	return true
+}
+
+// removeEpisodeCount removes episode count annotations from words (e.g.
+// "Episode 03 of 12", "03/12" or "12 eps"), and updates `*anime`.
+//
+// The boolean value reports whether an annotation was found.
+func removeEpisodeCount(words []segment, anime *Anime) ([]segment, bool) {
+	text, starts := joinSegments(words)
+
+	for _, re := range []*regexp.Regexp{regexpEpisodeOf, regexpEpisodeSlash} {
+		m := re.FindStringSubmatchIndex(text)
+		if m == nil {
+			continue
+		}
+
+		episode, err := strconv.Atoi(text[m[2]:m[3]])
+		if err != nil {
+			continue
+		}
+
+		total, err := strconv.Atoi(text[m[4]:m[5]])
+		if err != nil || episode > total {
+			continue
+		}
+
+		anime.Episode = episode
+		anime.TotalEpisodes = total
+		anime.Spans.Episode = []Span{joinedSpan(words, starts, m[2], m[3])}
+		anime.Spans.TotalEpisodes = []Span{joinedSpan(words, starts, m[4], m[5])}
+
+		return removeJoined(words, starts, m[0], m[1]), true
+	}
+
+	if m := regexpEpisodeCount.FindStringSubmatchIndex(text); m != nil {
+		if total, err := strconv.Atoi(text[m[2]:m[3]]); err == nil {
+			anime.TotalEpisodes = total
+			anime.Spans.TotalEpisodes = []Span{joinedSpan(words, starts, m[2], len(strings.TrimRightFunc(text[:m[1]], unicode.IsSpace)))}
+
+			return removeJoined(words, starts, m[0], m[1]), true
+		}
+	}
+
+	return words, false
+}
+
+// removeJoined returns words without the ones between the offsets i and j of
+// the result of `joinSegments(words)`.
+func removeJoined(words []segment, starts []int, i, j int) []segment {
+	kept := make([]segment, 0, len(words))
+
+	for k, word := range words {
+		if starts[k] >= i && starts[k]+len(word.text) <= j {
+			continue
+		}
+
+		kept = append(kept, word)
+	}
+
+	return kept
+}
+
 func splitByWords(s string) []string {
 	words := regexpWordSplit.Split(s, -1)
 
@@ -0,0 +1,92 @@
+package animenames
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Revision describes whether a release supersedes an earlier release of the
+// same file.
+type Revision struct {
+	Version int      // e.g. `2` in "03v2", or `0` when there's no version
+	Markers []string // e.g. "REPACK" or "PROPER"
+}
+
+// Number returns a value that's higher for newer releases of the same file.
+//
+// Releases without version are considered version 1, and each marker counts
+// as one more version.
+func (r Revision) Number() int {
+	version := r.Version
+	if version < 1 {
+		version = 1
+	}
+
+	return version + len(r.Markers)
+}
+
+var revisionMarkers = []string{
+	"fixed",
+	"proper",
+	"re-encode",
+	"repack",
+	"rerip",
+	"reupload",
+}
+
+// isRevisionMarker returns true when word is a known revision marker and false
+// otherwise.
+func (p *Parser) isRevisionMarker(word string) bool {
+	return p.keywords[word] == CategoryRevision
+}
+
+// isAfterEpisode returns true when one of the words before words[i] is an
+// episode number (e.g. "01", "S01E05" or "01-12").
+//
+// Revision markers outside parens are only recognized after the episode
+// number, so words like "Fixed" in "Fixed Star - 01" stay in the title.
+func isAfterEpisode(words []string, i int) bool {
+	for _, word := range words[:i] {
+		if regexpEpisode.MatchString(word) ||
+			regexpSeasonEpisode.MatchString(word) ||
+			regexpBatch.MatchString(word) ||
+			regexpCJKEpisode.MatchString(word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addRevisionMarker adds marker, found at span, to `anime.Revision`, unless
+// it's already there.
+func addRevisionMarker(anime *Anime, marker string, span Span) {
+	marker = strings.ToUpper(marker)
+
+	for _, m := range anime.Revision.Markers {
+		if m == marker {
+			return
+		}
+	}
+
+	anime.Revision.Markers = append(anime.Revision.Markers, marker)
+	anime.Spans.Revision = append(anime.Spans.Revision, span)
+}
+
+// parseVersion sets `anime.Revision.Version` from a version like "v2", found
+// in word, if it's not empty.
+func parseVersion(version string, word segment, anime *Anime) error {
+	if version == "" {
+		return nil
+	}
+
+	n, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(version), "v"))
+	if err != nil {
+		return err
+	}
+
+	anime.Revision.Version = n
+	anime.Spans.Revision = append(anime.Spans.Revision, word.span())
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package animenames
+
+import (
+	"strconv"
+	"strings"
+)
+
+// dotPrefixes contains lowercase words that are usually followed by a dot
+// that's not a separator (e.g. "Vol.1", "h.264" or "Dr.Stone").
+var dotPrefixes = []string{
+	"dr",
+	"h",
+	"mr",
+	"mrs",
+	"ms",
+	"st",
+	"vol",
+}
+
+var dotPrefixesMap = map[string]bool{}
+
+func init() {
+	for _, prefix := range dotPrefixes {
+		dotPrefixesMap[prefix] = true
+	}
+}
+
+// isDotSeparated returns true when name uses dots instead of spaces to
+// separate words, as in scene-style names like
+// "Spy.x.Family.S01E05.1080p.WEB.H264-GROUP".
+//
+// A single dot is only a separator when it's followed by a keyword or a season
+// and episode number (e.g. "Naruto.S01E01-GROUP"), so words like "Dr.Stone"
+// are kept.
+func (p *Parser) isDotSeparated(name string) bool {
+	if strings.ContainsAny(name, " _") {
+		return false
+	}
+
+	switch strings.Count(name, ".") {
+	case 0:
+		return false
+	case 1:
+		after := name[strings.Index(name, ".")+1:]
+		if i := strings.IndexFunc(after, isOpeningBracket); i != -1 {
+			after = after[:i]
+		}
+
+		after, _, _ = p.splitGroupSuffix(after)
+
+		return regexpSeasonEpisode.MatchString(after) || p.isKeyword(strings.ToLower(after))
+	}
+
+	return true
+}
+
+// splitDotSeparated replaces the dots separating words in name with spaces,
+// and returns the result along with the group found after the last dash, if
+// any.
+func (p *Parser) splitDotSeparated(name string) (string, string) {
+	words := p.splitDots(name)
+
+	// The group is after the last dash of the last word (e.g. "H264-GROUP").
+	//
+	// Words followed by parens (e.g. "S01E03-GROUP[1080p]") are left for
+	// the parser of chunks, which knows where the parens start.
+	group := ""
+	if last := words[len(words)-1]; strings.IndexFunc(last, isOpeningBracket) == -1 {
+		var (
+			prefix string
+			ok     bool
+		)
+
+		prefix, group, ok = p.splitGroupSuffix(last)
+		if ok {
+			words[len(words)-1] = prefix
+		}
+	}
+
+	return strings.Join(words, " "), group
+}
+
+// isOpeningBracket returns true when r is an opening bracket.
+func isOpeningBracket(r rune) bool {
+	return openingBrackets[r]
+}
+
+// splitDots splits s at the dots that separate words, keeping the dots that
+// don't (e.g. "Vol.1" or "AAC2.0").
+func (p *Parser) splitDots(s string) []string {
+	parts := strings.Split(s, ".")
+
+	words := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if len(words) > 0 {
+			prev := words[len(words)-1]
+
+			// Keep dots that are not separators.
+			if dotPrefixesMap[strings.ToLower(prev)] ||
+				regexpAudioChannels.MatchString(prev+"."+part) ||
+				p.isSingleKeyword(strings.ToLower(prev+"."+part)) {
+				words[len(words)-1] = prev + "." + part
+
+				continue
+			}
+		}
+
+		words = append(words, part)
+	}
+
+	return words
+}
+
+// removeSceneYear removes the year from the words of a scene-style name, and
+// updates `*anime`.
+//
+// Scene-style names have the year of movies after the title and before the
+// keywords (e.g. "Kimi.no.Na.wa.2016.1080p.BluRay.x264-GROUP"), so only the
+// last word that's not a keyword is taken, and only when there's a title
+// before it.
+//
+// The boolean value reports whether a year was found.
+func removeSceneYear(words []segment, anime *Anime) ([]segment, bool) {
+	if anime.Year != 0 || len(words) < 2 {
+		return words, false
+	}
+
+	last := words[len(words)-1]
+	if !regexpSceneYear.MatchString(last.text) {
+		return words, false
+	}
+
+	year, err := strconv.Atoi(last.text)
+	if err != nil {
+		return words, false
+	}
+
+	anime.Year = year
+	anime.Spans.Year = spansOf(last)
+
+	return words[:len(words)-1], true
+}
@@ -0,0 +1,170 @@
+package animenames
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Script is the writing system used by a text.
+type Script int
+
+const (
+	ScriptUnknown  Script = iota
+	ScriptLatin           // e.g. "Shingeki no Kyojin"
+	ScriptJapanese        // Kana or Kanji, e.g. "進撃の巨人"
+	ScriptHangul          // e.g. "진격의 거인"
+	ScriptCyrillic        // e.g. "Атака титанов"
+)
+
+// scriptOf returns the script of a single rune, or `ScriptUnknown` for digits,
+// punctuation and symbols.
+func scriptOf(r rune) Script {
+	switch {
+	case unicode.Is(unicode.Latin, r):
+		return ScriptLatin
+	case unicode.In(r, unicode.Hiragana, unicode.Katakana, unicode.Han):
+		return ScriptJapanese
+	case unicode.Is(unicode.Hangul, r):
+		return ScriptHangul
+	case unicode.Is(unicode.Cyrillic, r):
+		return ScriptCyrillic
+	}
+
+	return ScriptUnknown
+}
+
+// DetectScript returns the script used by most letters in s.
+func DetectScript(s string) Script {
+	counts := map[Script]int{}
+
+	for _, r := range s {
+		if script := scriptOf(r); script != ScriptUnknown {
+			counts[script]++
+		}
+	}
+
+	best := ScriptUnknown
+	for _, script := range []Script{ScriptLatin, ScriptJapanese, ScriptHangul, ScriptCyrillic} {
+		if counts[script] > counts[best] {
+			best = script
+		}
+	}
+
+	return best
+}
+
+// splitTitle sets `anime.TitleNative` and `anime.TitleRomaji` from
+// `anime.Title`, along with their spans.
+//
+// Titles are split either at a " / " or " | " separator, or where the words
+// change from one script to another (e.g. "葬送のフリーレン Sousou no Frieren").
+// When there's no clear split, the whole title is assigned to one of them,
+// depending on its script.
+func splitTitle(anime *Anime) {
+	title := anime.Title
+	if title == "" {
+		return
+	}
+
+	parts := regexpTitleSplit.Split(title, -1)
+	if len(parts) != 2 {
+		parts = splitByScript(title)
+	}
+
+	if len(parts) == 2 {
+		first := DetectScript(parts[0])
+		second := DetectScript(parts[1])
+
+		// The first part is at the beginning of the title, and the second
+		// one at the end.
+		firstSpans := titlePartSpans(anime, 0, parts[0])
+		secondSpans := titlePartSpans(anime, len(title)-len(parts[1]), parts[1])
+
+		if first != ScriptLatin && second == ScriptLatin {
+			anime.TitleNative = strings.TrimSpace(parts[0])
+			anime.TitleRomaji = strings.TrimSpace(parts[1])
+			anime.Spans.TitleNative = firstSpans
+			anime.Spans.TitleRomaji = secondSpans
+
+			return
+		}
+
+		if first == ScriptLatin && second != ScriptLatin && second != ScriptUnknown {
+			anime.TitleRomaji = strings.TrimSpace(parts[0])
+			anime.TitleNative = strings.TrimSpace(parts[1])
+			anime.Spans.TitleRomaji = firstSpans
+			anime.Spans.TitleNative = secondSpans
+
+			return
+		}
+	}
+
+	switch DetectScript(title) {
+	case ScriptLatin:
+		anime.TitleRomaji = title
+		anime.Spans.TitleRomaji = anime.Spans.Title
+	case ScriptUnknown:
+		// Nothing to do.
+	default:
+		anime.TitleNative = title
+		anime.Spans.TitleNative = anime.Spans.Title
+	}
+}
+
+// titlePartSpans returns the span of part, without surrounding whitespace,
+// where part is at offset i of `anime.Title`.
+func titlePartSpans(anime *Anime, i int, part string) []Span {
+	if len(anime.Spans.Title) == 0 {
+		return nil
+	}
+
+	title := anime.Spans.Title[0]
+
+	start := title.Start + i + len(part) - len(strings.TrimLeftFunc(part, unicode.IsSpace))
+	end := start + len(strings.TrimSpace(part))
+	if end > title.End {
+		end = title.End
+	}
+
+	return []Span{{Start: start, End: end}}
+}
+
+// splitByScript splits title in two parts where the words change from one
+// script to another.
+//
+// If the words change script more than once, or never, title is returned as a
+// single part.
+func splitByScript(title string) []string {
+	words := strings.Split(title, " ")
+
+	split := -1
+	current := ScriptUnknown
+
+	for i, word := range words {
+		script := DetectScript(word)
+
+		// Words without letters (e.g. numbers) belong to the current part.
+		if script == ScriptUnknown {
+			continue
+		}
+
+		if current != ScriptUnknown && script != current {
+			if split != -1 {
+				return []string{title}
+			}
+
+			split = i
+		}
+
+		current = script
+	}
+
+	if split == -1 {
+		return []string{title}
+	}
+
+	return []string{
+		strings.Join(words[:split], " "),
+		strings.Join(words[split:], " "),
+	}
+}
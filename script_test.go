@@ -0,0 +1,23 @@
+package animenames_test
+
+import (
+	"testing"
+
+	"github.com/c032/go-animenames"
+)
+
+var detectScriptTests = map[string]animenames.Script{
+	"Shingeki no Kyojin": animenames.ScriptLatin,
+	"進撃の巨人":              animenames.ScriptJapanese,
+	"진격의 거인":             animenames.ScriptHangul,
+	"Атака титанов":      animenames.ScriptCyrillic,
+	"86":                 animenames.ScriptUnknown,
+}
+
+func TestDetectScript(t *testing.T) {
+	for s, expected := range detectScriptTests {
+		if got := animenames.DetectScript(s); got != expected {
+			t.Errorf("animenames.DetectScript(%#v) = %#v; expected %#v", s, got, expected)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package animenames
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/c032/go-textutil"
+)
+
+// Span is a range of bytes in a name, e.g. `name[span.Start:span.End]`.
+type Span struct {
+	Start int
+	End   int
+}
+
+// Spans contains the positions in the name where the fields of `Anime` were
+// found.
+//
+// Fields can have more than one span (e.g. "[Group1][Group2]"), and fields
+// without a value have none.
+type Spans struct {
+	Title         []Span
+	TitleNative   []Span
+	TitleRomaji   []Span
+	Group         []Span // Also for `Groups`
+	Episode       []Span
+	Season        []Span
+	Volume        []Span
+	Year          []Span
+	YearEnd       []Span
+	Batch         []Span
+	Checksum      []Span // Also for `CRC32`
+	TotalEpisodes []Span
+	AiringSeason  []Span
+	Platform      []Span
+	Resolution    []Span
+	Language      []Span
+	Origin        []Span
+	HDR           []Span
+	FrameRate     []Span
+	Size          []Span
+	Duration      []Span
+	ReleaseDate   []Span
+	Revision      []Span
+	IsOVA         []Span
+	IsBD          []Span
+	IsRaw         []Span
+	HasSpecials   []Span
+	Subs          []Span
+
+	// Fields contains the spans of the tokens claimed by the extractor that
+	// set each field.
+	Fields map[string][]Span
+}
+
+// mapOffsets replaces the offsets of every span with `offsets[offset]`.
+//
+// `Fields` is left unchanged, because extractors run on the name before it's
+// normalized.
+func (s *Spans) mapOffsets(offsets []int) {
+	v := reflect.ValueOf(s).Elem()
+
+	for i := 0; i < v.NumField(); i++ {
+		spans, ok := v.Field(i).Interface().([]Span)
+		if !ok || spans == nil {
+			continue
+		}
+
+		// Fields can share spans (e.g. `IsRaw` and `Group`), so they're
+		// mapped into new slices.
+		mapped := make([]Span, len(spans))
+		for j, span := range spans {
+			mapped[j] = Span{
+				Start: offsets[span.Start],
+				End:   offsets[span.End],
+			}
+		}
+
+		v.Field(i).Set(reflect.ValueOf(mapped))
+	}
+}
+
+// segment is a piece of the name being parsed, and the byte offset where it
+// starts.
+type segment struct {
+	text  string
+	start int
+}
+
+// span returns the span of s.
+func (s segment) span() Span {
+	return Span{
+		Start: s.start,
+		End:   s.start + len(s.text),
+	}
+}
+
+// spansOf returns the span of s, or nil if s is empty.
+func spansOf(s segment) []Span {
+	if s.text == "" {
+		return nil
+	}
+
+	return []Span{s.span()}
+}
+
+// trimSegment returns s without leading and trailing whitespace.
+func trimSegment(s segment) segment {
+	text := strings.TrimLeftFunc(s.text, unicode.IsSpace)
+
+	return segment{
+		text:  strings.TrimRightFunc(text, unicode.IsSpace),
+		start: s.start + len(s.text) - len(text),
+	}
+}
+
+// stripSegment returns s without the parens wrapping it, if any.
+func stripSegment(s segment) segment {
+	text := textutil.StripParens(s.text)
+	if text == s.text {
+		return s
+	}
+
+	return segment{
+		text:  text,
+		start: s.start + strings.Index(s.text, text),
+	}
+}
+
+// splitParensSegments splits s in the same chunks as `textutil.SplitParens`.
+func splitParensSegments(s segment) []segment {
+	chunks := textutil.SplitParens(s.text)
+	segments := make([]segment, 0, len(chunks))
+
+	i := 0
+	for _, chunk := range chunks {
+		if j := strings.Index(s.text[i:], chunk); j != -1 {
+			i += j
+		}
+
+		segments = append(segments, segment{
+			text:  chunk,
+			start: s.start + i,
+		})
+
+		i += len(chunk)
+	}
+
+	return segments
+}
+
+// splitWordSegments splits s in the same words as `splitByWords`.
+func splitWordSegments(s segment) []segment {
+	words := make([]segment, 0)
+
+	i := 0
+	for _, loc := range regexpWordSplit.FindAllStringIndex(s.text, -1) {
+		words = append(words, segment{
+			text:  s.text[i:loc[0]],
+			start: s.start + i,
+		})
+
+		i = loc[1]
+	}
+
+	return append(words, segment{
+		text:  s.text[i:],
+		start: s.start + i,
+	})
+}
+
+// segmentTexts returns the text of each segment.
+func segmentTexts(segments []segment) []string {
+	texts := make([]string, 0, len(segments))
+	for _, s := range segments {
+		texts = append(texts, s.text)
+	}
+
+	return texts
+}
+
+// joinSegments returns the text of words separated by spaces, and the offset
+// of each word in it.
+func joinSegments(words []segment) (string, []int) {
+	starts := make([]int, 0, len(words))
+
+	i := 0
+	for _, word := range words {
+		starts = append(starts, i)
+		i += len(word.text) + 1
+	}
+
+	return strings.Join(segmentTexts(words), " "), starts
+}
+
+// joinedSpan returns the span of the text between the offsets i and j of the
+// result of `joinSegments(words)`.
+func joinedSpan(words []segment, starts []int, i, j int) Span {
+	span := Span{}
+	found := false
+
+	for k, word := range words {
+		end := starts[k] + len(word.text)
+
+		if !found && i >= starts[k] && i <= end {
+			span.Start = word.start + i - starts[k]
+			found = true
+		}
+
+		if j >= starts[k] && j <= end {
+			span.End = word.start + j - starts[k]
+
+			break
+		}
+	}
+
+	return span
+}
@@ -0,0 +1,165 @@
+package animenames_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/c032/go-animenames"
+)
+
+// spanTests maps names to the text of the spans of some fields.
+var spanTests = map[string]map[string]string{
+	"[Kantai] Eighty Six (86) - 23 (1920x1080 AC3) [05BD70FE].mkv": {
+		"Title":      "Eighty Six (86)",
+		"Group":      "Kantai",
+		"Episode":    "23",
+		"Checksum":   "05BD70FE",
+		"Resolution": "1920x1080",
+	},
+	"[Group] 86 - 86 [1080p]": {
+		"Title":   "86",
+		"Group":   "Group",
+		"Episode": "86",
+	},
+	"Spy.x.Family.S01E05.1080p.WEB.H264-GROUP.mkv": {
+		"Title":      "Spy.x.Family",
+		"Group":      "GROUP",
+		"Episode":    "S01E05",
+		"Season":     "S01E05",
+		"Resolution": "1080p",
+	},
+	"［Group］ Title （２０１３） - ０１": {
+		"Title":   "Title",
+		"Group":   "Group",
+		"Year":    "２０１３",
+		"Episode": "０１",
+	},
+	"[Group] 進撃の巨人 第二季 第05話 [1080p]": {
+		"Episode": "第05話",
+		"Season":  "第二季",
+	},
+}
+
+func TestParseSpans(t *testing.T) {
+	for name, expectedTexts := range spanTests {
+		gotAnime, err := animenames.Parse(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		gotSpans := map[string][]animenames.Span{
+			"Title":      gotAnime.Spans.Title,
+			"Group":      gotAnime.Spans.Group,
+			"Episode":    gotAnime.Spans.Episode,
+			"Season":     gotAnime.Spans.Season,
+			"Year":       gotAnime.Spans.Year,
+			"Checksum":   gotAnime.Spans.Checksum,
+			"Resolution": gotAnime.Spans.Resolution,
+		}
+
+		for field, expectedText := range expectedTexts {
+			spans := gotSpans[field]
+			if len(spans) != 1 {
+				t.Errorf("animenames.Parse(%#v).Spans.%s = %#v; expected one span", name, field, spans)
+
+				continue
+			}
+
+			if gotText := name[spans[0].Start:spans[0].End]; gotText != expectedText {
+				t.Errorf("animenames.Parse(%#v).Spans.%s has text %#v; expected %#v", name, field, gotText, expectedText)
+			}
+		}
+	}
+
+	// The same text can be in more than one field.
+	const name = "[Group] 86 - 86 [1080p]"
+
+	gotAnime, err := animenames.Parse(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotAnime.Spans.Title[0].Start == gotAnime.Spans.Episode[0].Start {
+		t.Errorf("animenames.Parse(%#v).Spans.Title and .Spans.Episode have the same span %#v", name, gotAnime.Spans.Title[0])
+	}
+}
+
+// spanFields maps fields of `Anime` to the field of `Spans` with their spans,
+// when the names are different.
+var spanFields = map[string]string{
+	"Groups": "Group",
+	"CRC32":  "Checksum",
+}
+
+// checkSpans reports the fields of anime that have a value but no spans, and
+// spans outside name.
+func checkSpans(t *testing.T, name string, anime animenames.Anime) {
+	t.Helper()
+
+	a := reflect.ValueOf(anime)
+	spans := reflect.ValueOf(anime.Spans)
+
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i).Name
+		if field == "Spans" || field == "Fields" || a.Field(i).IsZero() {
+			continue
+		}
+
+		spanField := field
+		if f, ok := spanFields[field]; ok {
+			spanField = f
+		}
+
+		gotSpans := spans.FieldByName(spanField).Interface().([]animenames.Span)
+		if len(gotSpans) == 0 {
+			t.Errorf("animenames.Parse(%#v).Spans.%s is empty; expected the span of %s", name, spanField, field)
+		}
+
+		for _, span := range gotSpans {
+			if span.Start < 0 || span.Start >= span.End || span.End > len(name) {
+				t.Errorf("animenames.Parse(%#v).Spans.%s has invalid span %#v", name, spanField, span)
+			}
+		}
+	}
+
+	for key := range anime.Fields {
+		if len(anime.Spans.Fields[key]) == 0 {
+			t.Errorf("animenames.Parse(%#v).Spans.Fields[%#v] is empty", name, key)
+		}
+	}
+}
+
+func TestParseSpansForEveryField(t *testing.T) {
+	for name := range parserTests {
+		gotAnime, err := animenames.Parse(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		checkSpans(t, name, gotAnime)
+	}
+}
+
+func TestExtractorSpans(t *testing.T) {
+	parser := animenames.NewParser(
+		animenames.WithExtractor(0, studioExtractor("MAPPA", "MAPPA")),
+	)
+
+	const name = "[Group] Jujutsu Kaisen - 01 [MAPPA][1080p]"
+
+	gotAnime, err := parser.Parse(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkSpans(t, name, gotAnime)
+
+	spans := gotAnime.Spans.Fields["studio"]
+	if len(spans) != 1 {
+		t.Fatalf("parser.Parse(%#v).Spans.Fields[%#v] = %#v; expected one span", name, "studio", spans)
+	}
+
+	if gotText := name[spans[0].Start:spans[0].End]; gotText != "MAPPA" {
+		t.Errorf("parser.Parse(%#v).Spans.Fields[%#v] has text %#v; expected %#v", name, "studio", gotText, "MAPPA")
+	}
+}
@@ -0,0 +1,134 @@
+package animenames
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// sizeUnits maps lowercase size units to their number of bytes.
+//
+// Sizes in names are usually computed with powers of 1024, even when written
+// as "GB", so both spellings are treated the same.
+var sizeUnits = map[string]int64{
+	"b":   1,
+	"kb":  1 << 10,
+	"kib": 1 << 10,
+	"mb":  1 << 20,
+	"mib": 1 << 20,
+	"gb":  1 << 30,
+	"gib": 1 << 30,
+	"tb":  1 << 40,
+	"tib": 1 << 40,
+}
+
+// unambiguousSizeUnits contains the lowercase size units that can't be
+// mistaken for words in titles (e.g. "2B" in "NieR Automata Ver1.1a - 2B").
+var unambiguousSizeUnits = map[string]bool{
+	"mb":  true,
+	"mib": true,
+	"gb":  true,
+	"gib": true,
+}
+
+// isTag returns true when s is a size, duration or date tag, and false
+// otherwise.
+func isTag(s string) bool {
+	return parseTag(segment{text: s}, &Anime{})
+}
+
+// isUnambiguousTag returns true when s is a tag that's unlikely to be part of
+// a title: a size in megabytes or gigabytes (e.g. "350MB"), a duration in
+// minutes (e.g. "24min") or written as "h:mm:ss", or a date.
+//
+// Short forms like "2B" or "3m" are only tags inside parens.
+func isUnambiguousTag(s string) bool {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if m := regexpSize.FindStringSubmatch(s); m != nil {
+		return unambiguousSizeUnits[m[2]]
+	}
+
+	if m := regexpDuration.FindStringSubmatch(s); m != nil && m[1]+m[2]+m[3] != "" {
+		return strings.Contains(s, "min")
+	}
+
+	return regexpClock.MatchString(s) || regexpDate.MatchString(s)
+}
+
+// parseTag updates `*anime` when s is a size (e.g. "1.2GB"), duration (e.g.
+// "23m40s" or "0:23:40") or date (e.g. "2023-10-05") tag.
+//
+// The boolean value reports whether s is one of them.
+func parseTag(tag segment, anime *Anime) bool {
+	tag = trimSegment(tag)
+	s := tag.text
+
+	if m := regexpSize.FindStringSubmatch(s); m != nil {
+		value, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return false
+		}
+
+		anime.Size = int64(value * float64(sizeUnits[strings.ToLower(m[2])]))
+		anime.Spans.Size = spansOf(tag)
+
+		return true
+	}
+
+	if m := regexpDuration.FindStringSubmatch(s); m != nil && m[1]+m[2]+m[3] != "" {
+		var duration time.Duration
+
+		units := []time.Duration{time.Hour, time.Minute, time.Second}
+		for i, unit := range units {
+			if m[i+1] == "" {
+				continue
+			}
+
+			n, err := strconv.Atoi(m[i+1])
+			if err != nil {
+				return false
+			}
+
+			duration += time.Duration(n) * unit
+		}
+
+		anime.Duration = duration
+		anime.Spans.Duration = spansOf(tag)
+
+		return true
+	}
+
+	if m := regexpClock.FindStringSubmatch(s); m != nil {
+		var duration time.Duration
+
+		units := []time.Duration{time.Hour, time.Minute, time.Second}
+		for i, unit := range units {
+			n, err := strconv.Atoi(m[i+1])
+			if err != nil {
+				return false
+			}
+
+			duration += time.Duration(n) * unit
+		}
+
+		anime.Duration = duration
+		anime.Spans.Duration = spansOf(tag)
+
+		return true
+	}
+
+	if m := regexpDate.FindStringSubmatch(s); m != nil {
+		date, err := time.Parse("2006-01-02", m[1]+"-"+m[2]+"-"+m[3])
+		if err != nil {
+			return false
+		}
+
+		anime.ReleaseDate = date
+		anime.Spans.ReleaseDate = spansOf(tag)
+
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,305 @@
+package animenames
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var regexpNumber = regexp.MustCompile(`^[0-9]+(?:\.[0-9]+|v[0-9]+)?$`)
+
+// TokenKind describes what a token is.
+type TokenKind int
+
+const (
+	TokenUnknown   TokenKind = iota
+	TokenKeyword             // e.g. "1080p" or "HEVC"
+	TokenNumber              // e.g. "01", "12.5" or "03v2"
+	TokenChecksum            // e.g. "05BD70FE"
+	TokenSeparator           // Brackets, underscores and words like "-"
+)
+
+// Token is a word from a name.
+type Token struct {
+	Text  string // As written in the name
+	Start int    // Byte offset of the first byte in the name
+	End   int    // Byte offset after the last byte in the name
+	Depth int    // Number of brackets around the token
+
+	// Bracket is the innermost opening bracket around the token (e.g. '['
+	// or '【'), or 0. Fullwidth brackets are normalized, so "［" is '['.
+	Bracket rune
+
+	Kind TokenKind
+
+	// Category is the category of keywords, or empty for other tokens and
+	// for keywords without a category (e.g. sizes like "1.2GB").
+	Category KeywordCategory
+}
+
+// openingBrackets and closingBrackets contain the brackets recognized by the
+// tokenizer, after normalizing.
+var (
+	openingBrackets = map[rune]bool{'(': true, '[': true, '{': true, '【': true}
+	closingBrackets = map[rune]bool{')': true, ']': true, '}': true, '】': true}
+)
+
+// Tokenize returns the tokens from name, using the built-in keywords.
+func Tokenize(name string) []Token {
+	return defaultParser.Tokenize(name)
+}
+
+// Tokenize returns the tokens from name.
+//
+// Words are separated by brackets, whitespace and underscores, the same way
+// names are split when parsing. Brackets and underscores are also tokens, but
+// whitespace isn't. Fullwidth characters are recognized, but tokens keep the
+// text and offsets from name.
+//
+// The extension is split from the last word (e.g. "01.mkv"), and scene-style
+// names (e.g. "Spy.x.Family.S01E05.1080p.WEB.H264-GROUP") are also split at
+// the dots between words, and before the group.
+func (p *Parser) Tokenize(name string) []Token {
+	var (
+		tokens = make([]Token, 0)
+
+		// brackets contains the opening brackets around the current
+		// position.
+		brackets = make([]rune, 0)
+
+		start = -1
+	)
+
+	add := func(start, end int, kind TokenKind) {
+		token := Token{
+			Text:  name[start:end],
+			Start: start,
+			End:   end,
+			Depth: len(brackets),
+			Kind:  kind,
+		}
+
+		if len(brackets) > 0 {
+			token.Bracket = brackets[len(brackets)-1]
+		}
+
+		if kind == TokenUnknown {
+			token.Kind, token.Category = p.classify(normalize(token.Text), len(brackets) > 0)
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	flush := func(end int) {
+		if start == -1 {
+			return
+		}
+
+		add(start, end, TokenUnknown)
+
+		start = -1
+	}
+
+	for i, r := range name {
+		end := i + len(string(r))
+		r = normalizeRune(r)
+
+		switch {
+		case openingBrackets[r]:
+			flush(i)
+			add(i, end, TokenSeparator)
+			brackets = append(brackets, r)
+		case closingBrackets[r]:
+			flush(i)
+			if len(brackets) > 0 {
+				brackets = brackets[:len(brackets)-1]
+			}
+			add(i, end, TokenSeparator)
+		case r == '_':
+			flush(i)
+			add(i, end, TokenSeparator)
+		case unicode.IsSpace(r):
+			flush(i)
+		default:
+			if start == -1 {
+				start = i
+			}
+		}
+	}
+
+	flush(len(name))
+
+	if p.isDotSeparated(p.trimExtension(normalize(name))) {
+		tokens = p.splitSceneTokens(tokens)
+	} else {
+		tokens = p.splitExtension(tokens)
+	}
+
+	return tokens
+}
+
+// subToken returns the part of token with the given text, which starts at the
+// byte offset start of the name.
+func (p *Parser) subToken(token Token, text string, start int, kind TokenKind) Token {
+	token.Text = text
+	token.Start = start
+	token.End = start + len(text)
+	token.Kind = kind
+	token.Category = ""
+
+	if kind == TokenUnknown {
+		token.Kind, token.Category = p.classify(normalize(text), token.Depth > 0)
+	}
+
+	return token
+}
+
+// splitExtension splits the extension from the last token outside brackets
+// (e.g. "mkv" in "01.mkv" or in the ".mkv" after "[1080p]"), like
+// `trimExtension` does when parsing.
+func (p *Parser) splitExtension(tokens []Token) []Token {
+	if len(tokens) == 0 {
+		return tokens
+	}
+
+	token := tokens[len(tokens)-1]
+	if token.Kind == TokenSeparator || token.Depth > 0 {
+		return tokens
+	}
+
+	i := strings.LastIndex(token.Text, ".")
+	if i == -1 || !p.isExtension(strings.ToLower(normalize(token.Text[i+1:]))) {
+		return tokens
+	}
+
+	tokens = tokens[:len(tokens)-1]
+
+	if i > 0 {
+		tokens = append(tokens, p.subToken(token, token.Text[:i], token.Start, TokenUnknown))
+	}
+
+	return append(tokens,
+		p.subToken(token, ".", token.Start+i, TokenSeparator),
+		p.subToken(token, token.Text[i+1:], token.Start+i+1, TokenUnknown),
+	)
+}
+
+// splitSceneTokens splits the tokens outside brackets at the dots that
+// separate words, and splits the group from the last word (e.g. "GROUP" in
+// "H264-GROUP.mkv").
+func (p *Parser) splitSceneTokens(tokens []Token) []Token {
+	split := make([]Token, 0, len(tokens))
+
+	add := func(token Token, text string, start int, kind TokenKind) {
+		split = append(split, p.subToken(token, text, start, kind))
+	}
+
+	for _, token := range tokens {
+		if token.Kind == TokenSeparator || token.Depth > 0 || !strings.Contains(token.Text, ".") {
+			split = append(split, token)
+
+			continue
+		}
+
+		start := token.Start
+		for i, part := range p.splitDots(token.Text) {
+			if i > 0 {
+				add(token, ".", start, TokenSeparator)
+				start++
+			}
+
+			if part != "" {
+				add(token, part, start, TokenUnknown)
+			}
+
+			start += len(part)
+		}
+	}
+
+	// The group is in the last word outside brackets, before the extension.
+	last := -1
+	extension := false
+	for i := len(split) - 1; i >= 0; i-- {
+		if split[i].Kind == TokenSeparator || split[i].Depth > 0 {
+			continue
+		}
+
+		if !extension && i > 0 && split[i].Category == CategoryExtension && split[i-1].Text == "." {
+			extension = true
+
+			continue
+		}
+
+		last = i
+
+		break
+	}
+
+	if last == -1 {
+		return split
+	}
+
+	token := split[last]
+
+	prefix, group, ok := p.splitGroupSuffix(token.Text)
+	if !ok {
+		return split
+	}
+
+	rest := append([]Token{}, split[last+1:]...)
+	split = split[:last]
+
+	if prefix != "" {
+		add(token, prefix, token.Start, TokenUnknown)
+	}
+
+	add(token, "-", token.Start+len(prefix), TokenSeparator)
+	add(token, group, token.Start+len(prefix)+1, TokenUnknown)
+
+	return append(split, rest...)
+}
+
+// classify returns the kind of a normalized word and, for keywords, their
+// category.
+//
+// Numbers are only checksums inside brackets, so dates like "20231005" outside
+// them are numbers.
+func (p *Parser) classify(word string, bracketed bool) (TokenKind, KeywordCategory) {
+	lword := strings.ToLower(word)
+
+	if p.isKeyword(lword) {
+		if category, ok := p.keywords[p.normalizeKeyword(lword)]; ok {
+			return TokenKeyword, category
+		}
+
+		if regexpFrameRate.MatchString(lword) {
+			return TokenKeyword, CategoryFrameRate
+		}
+
+		if regexpVersion.MatchString(lword) {
+			return TokenKeyword, CategoryRevision
+		}
+
+		return TokenKeyword, ""
+	}
+
+	if checksum, ok := parseChecksum(word); ok && (bracketed || isMixedChecksum(checksum)) {
+		return TokenChecksum, ""
+	}
+
+	if regexpNumber.MatchString(word) {
+		return TokenNumber, ""
+	}
+
+	if strings.IndexFunc(word, isWordRune) == -1 {
+		return TokenSeparator, ""
+	}
+
+	return TokenUnknown, ""
+}
+
+// isWordRune returns true when r is part of words, instead of being
+// punctuation like "-" or "~".
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
@@ -0,0 +1,119 @@
+package animenames_test
+
+import (
+	"testing"
+
+	"github.com/c032/go-animenames"
+)
+
+func TestTokenize(t *testing.T) {
+	const name = "[Group] Title - 01 (1080p HEVC)_【GB】 [05BD70FE]"
+
+	expectedTokens := []animenames.Token{
+		{Text: "[", Start: 0, End: 1, Kind: animenames.TokenSeparator},
+		{Text: "Group", Start: 1, End: 6, Depth: 1, Bracket: '['},
+		{Text: "]", Start: 6, End: 7, Kind: animenames.TokenSeparator},
+		{Text: "Title", Start: 8, End: 13},
+		{Text: "-", Start: 14, End: 15, Kind: animenames.TokenSeparator},
+		{Text: "01", Start: 16, End: 18, Kind: animenames.TokenNumber},
+		{Text: "(", Start: 19, End: 20, Kind: animenames.TokenSeparator},
+		{Text: "1080p", Start: 20, End: 25, Depth: 1, Bracket: '(', Kind: animenames.TokenKeyword, Category: animenames.CategoryResolution},
+		{Text: "HEVC", Start: 26, End: 30, Depth: 1, Bracket: '(', Kind: animenames.TokenKeyword, Category: animenames.CategoryVideoCodec},
+		{Text: ")", Start: 30, End: 31, Kind: animenames.TokenSeparator},
+		{Text: "_", Start: 31, End: 32, Kind: animenames.TokenSeparator},
+		{Text: "【", Start: 32, End: 35, Kind: animenames.TokenSeparator},
+		{Text: "GB", Start: 35, End: 37, Depth: 1, Bracket: '【'},
+		{Text: "】", Start: 37, End: 40, Kind: animenames.TokenSeparator},
+		{Text: "[", Start: 41, End: 42, Kind: animenames.TokenSeparator},
+		{Text: "05BD70FE", Start: 42, End: 50, Depth: 1, Bracket: '[', Kind: animenames.TokenChecksum},
+		{Text: "]", Start: 50, End: 51, Kind: animenames.TokenSeparator},
+	}
+
+	gotTokens := animenames.Tokenize(name)
+	if len(gotTokens) != len(expectedTokens) {
+		t.Fatalf("len(animenames.Tokenize(%#v)) = %d; expected %d", name, len(gotTokens), len(expectedTokens))
+	}
+
+	for i, expectedToken := range expectedTokens {
+		if gotTokens[i] != expectedToken {
+			t.Errorf("animenames.Tokenize(%#v)[%d] = %#v; expected %#v", name, i, gotTokens[i], expectedToken)
+		}
+
+		if text := name[gotTokens[i].Start:gotTokens[i].End]; text != gotTokens[i].Text {
+			t.Errorf("animenames.Tokenize(%#v)[%d] has offsets of %#v; expected %#v", name, i, text, gotTokens[i].Text)
+		}
+	}
+}
+
+func TestTokenizeKinds(t *testing.T) {
+	tests := map[string][]animenames.Token{
+		// Numbers outside brackets are not checksums.
+		"Title - 20231005 [20231005]": {
+			{Text: "Title", Start: 0, End: 5},
+			{Text: "-", Start: 6, End: 7, Kind: animenames.TokenSeparator},
+			{Text: "20231005", Start: 8, End: 16, Kind: animenames.TokenNumber},
+			{Text: "[", Start: 17, End: 18, Kind: animenames.TokenSeparator},
+			{Text: "20231005", Start: 18, End: 26, Depth: 1, Bracket: '[', Kind: animenames.TokenChecksum},
+			{Text: "]", Start: 26, End: 27, Kind: animenames.TokenSeparator},
+		},
+		"Spy.x.Family.S01E05.1080p.WEB.H264-GROUP.mkv": {
+			{Text: "Spy", Start: 0, End: 3},
+			{Text: ".", Start: 3, End: 4, Kind: animenames.TokenSeparator},
+			{Text: "x", Start: 4, End: 5},
+			{Text: ".", Start: 5, End: 6, Kind: animenames.TokenSeparator},
+			{Text: "Family", Start: 6, End: 12},
+			{Text: ".", Start: 12, End: 13, Kind: animenames.TokenSeparator},
+			{Text: "S01E05", Start: 13, End: 19},
+			{Text: ".", Start: 19, End: 20, Kind: animenames.TokenSeparator},
+			{Text: "1080p", Start: 20, End: 25, Kind: animenames.TokenKeyword, Category: animenames.CategoryResolution},
+			{Text: ".", Start: 25, End: 26, Kind: animenames.TokenSeparator},
+			{Text: "WEB", Start: 26, End: 29, Kind: animenames.TokenKeyword, Category: animenames.CategoryQuality},
+			{Text: ".", Start: 29, End: 30, Kind: animenames.TokenSeparator},
+			{Text: "H264", Start: 30, End: 34, Kind: animenames.TokenKeyword, Category: animenames.CategoryVideoCodec},
+			{Text: "-", Start: 34, End: 35, Kind: animenames.TokenSeparator},
+			{Text: "GROUP", Start: 35, End: 40},
+			{Text: ".", Start: 40, End: 41, Kind: animenames.TokenSeparator},
+			{Text: "mkv", Start: 41, End: 44, Kind: animenames.TokenKeyword, Category: animenames.CategoryExtension},
+		},
+		"[Group] Title - 01 [1080p].mkv": {
+			{Text: "[", Start: 0, End: 1, Kind: animenames.TokenSeparator},
+			{Text: "Group", Start: 1, End: 6, Depth: 1, Bracket: '['},
+			{Text: "]", Start: 6, End: 7, Kind: animenames.TokenSeparator},
+			{Text: "Title", Start: 8, End: 13},
+			{Text: "-", Start: 14, End: 15, Kind: animenames.TokenSeparator},
+			{Text: "01", Start: 16, End: 18, Kind: animenames.TokenNumber},
+			{Text: "[", Start: 19, End: 20, Kind: animenames.TokenSeparator},
+			{Text: "1080p", Start: 20, End: 25, Depth: 1, Bracket: '[', Kind: animenames.TokenKeyword, Category: animenames.CategoryResolution},
+			{Text: "]", Start: 25, End: 26, Kind: animenames.TokenSeparator},
+			{Text: ".", Start: 26, End: 27, Kind: animenames.TokenSeparator},
+			{Text: "mkv", Start: 27, End: 30, Kind: animenames.TokenKeyword, Category: animenames.CategoryExtension},
+		},
+		"Title - 01.mkv": {
+			{Text: "Title", Start: 0, End: 5},
+			{Text: "-", Start: 6, End: 7, Kind: animenames.TokenSeparator},
+			{Text: "01", Start: 8, End: 10, Kind: animenames.TokenNumber},
+			{Text: ".", Start: 10, End: 11, Kind: animenames.TokenSeparator},
+			{Text: "mkv", Start: 11, End: 14, Kind: animenames.TokenKeyword, Category: animenames.CategoryExtension},
+		},
+		"Title - 01v2": {
+			{Text: "Title", Start: 0, End: 5},
+			{Text: "-", Start: 6, End: 7, Kind: animenames.TokenSeparator},
+			{Text: "01v2", Start: 8, End: 12, Kind: animenames.TokenNumber},
+		},
+	}
+
+	for name, expectedTokens := range tests {
+		gotTokens := animenames.Tokenize(name)
+		if len(gotTokens) != len(expectedTokens) {
+			t.Errorf("len(animenames.Tokenize(%#v)) = %d; expected %d", name, len(gotTokens), len(expectedTokens))
+
+			continue
+		}
+
+		for i, expectedToken := range expectedTokens {
+			if gotTokens[i] != expectedToken {
+				t.Errorf("animenames.Tokenize(%#v)[%d] = %#v; expected %#v", name, i, gotTokens[i], expectedToken)
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package animenames
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Decision is a step taken by the parser, e.g. taking a chunk as the episode
+// number.
+type Decision struct {
+	Chunk   string   // Text the rule was applied to
+	Rule    string   // e.g. "episode number"
+	Changes []Change // Fields set or overwritten by the rule
+}
+
+// Change describes a field modified by a rule.
+type Change struct {
+	Field string
+	Old   string // Empty when the field didn't have a value
+	New   string // Empty when the field was reset
+}
+
+// Trace contains the decisions taken while parsing a name, in order.
+type Trace []Decision
+
+// String returns the trace with one decision per line, e.g.:
+//
+//	"01": episode number (Episode: "" -> "1")
+func (t Trace) String() string {
+	var b strings.Builder
+
+	for _, d := range t {
+		fmt.Fprintf(&b, "%#v: %s", d.Chunk, d.Rule)
+
+		changes := make([]string, 0, len(d.Changes))
+		for _, c := range d.Changes {
+			changes = append(changes, fmt.Sprintf("%s: %#v -> %#v", c.Field, c.Old, c.New))
+		}
+
+		if len(changes) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(changes, ", "))
+		}
+
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// ParseWithTrace returns anime information from a file name, using the
+// built-in keywords, and the decisions taken to get it.
+func ParseWithTrace(name string) (Anime, Trace, error) {
+	return defaultParser.ParseWithTrace(name)
+}
+
+// ParseWithTrace returns anime information from a file name, and the
+// decisions taken to get it.
+func (p *Parser) ParseWithTrace(name string) (Anime, Trace, error) {
+	// The copy shares the keyword tables, which are never modified, but
+	// records decisions in its own trace.
+	tp := *p
+	tp.trace = &Trace{}
+
+	anime, err := tp.Parse(name)
+
+	return anime, *tp.trace, err
+}
+
+// snapshot returns a copy of anime to compare against in `decide`, or nil when
+// not tracing, so the parser doesn't copy it at every step.
+func (p *Parser) snapshot(anime *Anime) *Anime {
+	if p.trace == nil {
+		return nil
+	}
+
+	before := *anime
+
+	return &before
+}
+
+// decide records a decision when tracing, with the fields of after that are
+// different from before, which must come from `snapshot`.
+func (p *Parser) decide(chunk, rule string, before, after *Anime) {
+	if p.trace == nil {
+		return
+	}
+
+	*p.trace = append(*p.trace, Decision{
+		Chunk:   chunk,
+		Rule:    rule,
+		Changes: diffAnime(*before, *after),
+	})
+}
+
+// diffAnime returns the fields that are different between before and after.
+func diffAnime(before, after Anime) []Change {
+	changes := make([]Change, 0)
+
+	b := reflect.ValueOf(before)
+	a := reflect.ValueOf(after)
+
+	for i := 0; i < b.NumField(); i++ {
+		field := b.Type().Field(i).Name
+
+		// Spans change along with the fields they belong to.
+		if field == "Spans" {
+			continue
+		}
+
+		if reflect.DeepEqual(b.Field(i).Interface(), a.Field(i).Interface()) {
+			continue
+		}
+
+		changes = append(changes, Change{
+			Field: field,
+			Old:   formatValue(b.Field(i)),
+			New:   formatValue(a.Field(i)),
+		})
+	}
+
+	return changes
+}
+
+// formatValue returns v as text for a `Change`, or an empty string if v is a
+// zero value.
+func formatValue(v reflect.Value) string {
+	if v.IsZero() {
+		return ""
+	}
+
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.Format("2006-01-02")
+	}
+
+	if v.Kind() == reflect.Struct {
+		return fmt.Sprintf("%+v", v.Interface())
+	}
+
+	return fmt.Sprint(v.Interface())
+}
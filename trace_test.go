@@ -0,0 +1,119 @@
+package animenames_test
+
+import (
+	"testing"
+
+	"github.com/c032/go-animenames"
+)
+
+func TestParseWithTrace(t *testing.T) {
+	const name = "[Kantai] Eighty Six (86) - 23 (1920x1080 AC3) [05BD70FE].mkv"
+
+	expectedAnime, err := animenames.Parse(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gotAnime, trace, err := animenames.ParseWithTrace(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if gotAnime.Title != expectedAnime.Title || gotAnime.Episode != expectedAnime.Episode || gotAnime.Group != expectedAnime.Group {
+		t.Errorf("animenames.ParseWithTrace(%#v) = %#v; expected %#v", name, gotAnime, expectedAnime)
+	}
+
+	// Rules that set each field, in the order they're expected.
+	expectedRules := []struct {
+		Rule  string
+		Field string
+		New   string
+	}{
+		{"checksum inside parens", "CRC32", "05BD70FE"},
+		{"group at the leftmost chunk", "Group", "Kantai"},
+		{"episode number", "Episode", "23"},
+		{"title", "Title", "Eighty Six (86)"},
+	}
+
+	i := 0
+	for _, decision := range trace {
+		if i == len(expectedRules) {
+			break
+		}
+
+		if decision.Rule != expectedRules[i].Rule {
+			continue
+		}
+
+		found := false
+		for _, change := range decision.Changes {
+			if change.Field == expectedRules[i].Field && change.New == expectedRules[i].New {
+				found = true
+			}
+		}
+
+		if !found {
+			t.Errorf("decision %#v doesn't set %s to %#v", decision, expectedRules[i].Field, expectedRules[i].New)
+		}
+
+		i++
+	}
+
+	if i != len(expectedRules) {
+		t.Errorf("animenames.ParseWithTrace(%#v) trace doesn't contain rule %#v:\n%s", name, expectedRules[i].Rule, trace)
+	}
+}
+
+func TestParseWithTraceOverwrite(t *testing.T) {
+	// The season is reset when more than one season is found.
+	const name = "[DeadFish] Working!! - S1 & S2 [BD][720p][MP4][AAC]"
+
+	_, trace, err := animenames.ParseWithTrace(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, decision := range trace {
+		if decision.Rule != "more than one season" {
+			continue
+		}
+
+		if len(decision.Changes) != 1 || decision.Changes[0].Field != "Season" || decision.Changes[0].Old == "" || decision.Changes[0].New != "" {
+			t.Errorf("decision %#v doesn't reset the season", decision)
+		}
+
+		return
+	}
+
+	t.Errorf("animenames.ParseWithTrace(%#v) trace doesn't reset the season:\n%s", name, trace)
+}
+
+func TestParseWithTraceEnums(t *testing.T) {
+	const name = "[Group] Title - 01 [2160p HDR10][Hardsub]"
+
+	_, trace, err := animenames.ParseWithTrace(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedValues := map[string]string{
+		"HDR":  "HDR10",
+		"Subs": "Hard",
+	}
+
+	for _, decision := range trace {
+		for _, change := range decision.Changes {
+			if expected, ok := expectedValues[change.Field]; ok {
+				if change.New != expected {
+					t.Errorf("decision %#v sets %s to %#v; expected %#v", decision, change.Field, change.New, expected)
+				}
+
+				delete(expectedValues, change.Field)
+			}
+		}
+	}
+
+	for field := range expectedValues {
+		t.Errorf("animenames.ParseWithTrace(%#v) trace doesn't set %s:\n%s", name, field, trace)
+	}
+}